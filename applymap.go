@@ -0,0 +1,33 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// applyMap sets every key of the map m, whose keys are strings and whose
+// values are interfaces, to the value of the type named by the key. Keys are
+// resolved in order, so that the first error is the same on every call.
+func (inj *injector) applyMap(m reflect.Value) error {
+	t := m.Type()
+	if t.Key().Kind() != reflect.String || t.Elem().Kind() != reflect.Interface {
+		return nil
+	}
+	if tr := inj.opts.trace; tr != nil {
+		tr.printf("apply %v", t)
+	}
+	keys := m.MapKeys()
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+	for _, key := range keys {
+		v, err := inj.ValueByName(key.String())
+		if err != nil {
+			return err
+		}
+		if !v.Type().AssignableTo(t.Elem()) {
+			return fmt.Errorf("%w: %v is not assignable to %v (required by %v)", ErrValueCanNotSet, v.Type(), t.Elem(), t)
+		}
+		m.SetMapIndex(key, v)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package inject
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestApply_Map(t *testing.T) {
+	inj := New()
+	inj.Map(&greeter{"Jeremy"}, "a dep")
+
+	config := map[string]interface{}{
+		"string":          nil,
+		"*inject.greeter": nil,
+		"github.com/juanjiTech/inject/v2.*greeter": "replaced",
+	}
+	expect(t, inj.Apply(config), nil)
+	expect(t, config["string"], "a dep")
+	expect(t, config["*inject.greeter"].(*greeter).Name, "Jeremy")
+	expect(t, config["github.com/juanjiTech/inject/v2.*greeter"].(*greeter).Name, "Jeremy")
+
+	ptr := map[string]interface{}{"string": nil}
+	expect(t, inj.Child().Apply(&ptr), nil)
+	expect(t, ptr["string"], "a dep")
+
+	stringers := map[string]fmt.Stringer{"string": nil}
+	expect(t, errors.Is(inj.Apply(stringers), ErrValueCanNotSet), true)
+	expect(t, errors.Is(inj.Apply(map[string]interface{}{"int": nil}), ErrValueNotFound), true)
+	expect(t, inj.Apply(map[int]interface{}{1: nil}), nil)
+}
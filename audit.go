@@ -0,0 +1,80 @@
+package inject
+
+import (
+	"reflect"
+	"time"
+)
+
+// AuditEntry records a change of the bindings of an injector, see
+// WithAuditLog.
+type AuditEntry struct {
+	// Time is when the change happened.
+	Time time.Time
+	// Method is the registration method, such as "Map" or "Provide", or
+	// "Reset" and "ResetTo" for removals.
+	Method string
+	// Type is the bound type. It is nil for removals.
+	Type reflect.Type
+	// Replaced reports whether the change replaced an existing binding of
+	// Type in the same injector.
+	Replaced bool
+	// File and Line locate the call making the change.
+	File string
+	Line int
+}
+
+// WithAuditLog makes the Injector and its children record the last size
+// changes of their bindings with the calling file and line, to be retrieved
+// with AuditLog, e.g. to find out after an incident what rebound a type.
+// Every injector keeps its own log, allocated on its first change.
+func WithAuditLog(size int) Option {
+	return func(o *options) {
+		o.auditSize = size
+	}
+}
+
+// auditLog is a ring buffer of the latest changes of an injector.
+type auditLog struct {
+	entries []AuditEntry
+	next    int
+}
+
+// audit records a change of t by method at site. The caller must hold the
+// write lock.
+func (inj *injector) audit(method string, t reflect.Type, site *callSite) {
+	if inj.opts.conflicts != nil && t != nil {
+		inj.noteDuplicate(method, t, site)
+	}
+	size := inj.opts.auditSize
+	if size <= 0 || site == nil {
+		return
+	}
+	e := AuditEntry{Time: site.time, Method: method, Type: t, File: site.file, Line: site.line}
+	if t != nil {
+		_, e.Replaced = inj.values[t]
+	}
+	ext := inj.extend()
+	if ext.audits == nil {
+		ext.audits = &auditLog{}
+	}
+	l := ext.audits
+	if len(l.entries) < size {
+		l.entries = append(l.entries, e)
+		return
+	}
+	l.entries[l.next] = e
+	l.next = (l.next + 1) % size
+}
+
+func (inj *injector) AuditLog() []AuditEntry {
+	inj.mu.RLock()
+	defer inj.mu.RUnlock()
+	ext := inj.extension()
+	if ext == nil || ext.audits == nil {
+		return nil
+	}
+	l := ext.audits
+	out := make([]AuditEntry, 0, len(l.entries))
+	out = append(out, l.entries[l.next:]...)
+	return append(out, l.entries[:l.next]...)
+}
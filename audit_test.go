@@ -0,0 +1,45 @@
+package inject
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+func TestWithAuditLog(t *testing.T) {
+	inj := New(WithAuditLog(4))
+	expect(t, len(inj.AuditLog()), 0)
+
+	inj.Map("a dep")
+	inj.MapTo(&greeter{"Jeremy"}, (*fmt.Stringer)(nil))
+	inj.Map("rebound")
+	expect(t, inj.Provide(func() *testRepo { return nil }), nil)
+
+	log := inj.AuditLog()
+	expect(t, len(log), 4)
+	expect(t, log[0].Method, "Map")
+	expect(t, log[0].Type, Type[string]())
+	expect(t, log[0].Replaced, false)
+	expect(t, filepath.Base(log[0].File), "audit_test.go")
+	expect(t, log[0].Line > 0, true)
+	expect(t, log[0].Time.IsZero(), false)
+	expect(t, log[1].Method, "MapTo")
+	expect(t, log[2].Replaced, true)
+	expect(t, log[3].Method, "Provide")
+
+	inj.Reset(KeepParent)
+	log = inj.AuditLog()
+	expect(t, len(log), 4)
+	expect(t, log[0].Method, "MapTo")
+	expect(t, log[3].Method, "Reset")
+	expect(t, log[3].Type, nil)
+
+	child := inj.Child()
+	expect(t, len(child.AuditLog()), 0)
+	child.Map(1)
+	expect(t, len(child.AuditLog()), 1)
+
+	untracked := New()
+	untracked.Map("a dep")
+	expect(t, len(untracked.AuditLog()), 0)
+}
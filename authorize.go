@@ -0,0 +1,93 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ResolutionRequest describes a resolution about to return a value, see
+// WithAuthorization.
+type ResolutionRequest struct {
+	// Type is the requested type.
+	Type reflect.Type
+	// Consumer names what requested the type: the invoked function or
+	// constructor, or the struct field being applied.
+	Consumer string
+	// Binding is the binding resolving the request. Its Meta holds the
+	// attributes attached with Meta or WithMeta.
+	Binding BindingInfo
+	// Scopes are the labels of the parent chain of the injector resolving
+	// the request, starting with that injector. Unlabeled injectors have an
+	// empty label.
+	Scopes []string
+}
+
+// AuthorizationError is returned when a check given to WithAuthorization
+// denies a resolution. It matches ErrDenied with errors.Is.
+type AuthorizationError struct {
+	Request ResolutionRequest
+	// Err is the error returned by the check.
+	Err error
+}
+
+func (e *AuthorizationError) Error() string {
+	msg := fmt.Sprintf("%v: %v", ErrDenied, e.Request.Type)
+	if e.Request.Consumer != "" {
+		msg += " required by " + e.Request.Consumer
+	}
+	return msg + ": " + e.Err.Error()
+}
+
+func (e *AuthorizationError) Unwrap() error {
+	return e.Err
+}
+
+func (e *AuthorizationError) Is(target error) bool {
+	return target == ErrDenied
+}
+
+// WithAuthorization makes the Injector and its children call check before
+// returning the value of every resolution, and fail the resolution with an
+// AuthorizationError if check returns an error, e.g. to enforce that handlers
+// do not inject repositories directly. check runs before providers construct
+// their value. Checks given to an injector and to its parent must all pass,
+// the parent's is called first. Like with Deny, providers resolving their
+// arguments from the injector they were provided to are only checked by its
+// checks. check is called on the resolving goroutine, so it must be safe for
+// concurrent use.
+func WithAuthorization(check func(ResolutionRequest) error) Option {
+	return func(o *options) {
+		if prev := o.authorize; prev != nil {
+			o.authorize = func(r ResolutionRequest) error {
+				if err := prev(r); err != nil {
+					return err
+				}
+				return check(r)
+			}
+			return
+		}
+		o.authorize = check
+	}
+}
+
+// authorized calls the authorization check of inj for the resolution of t
+// for consumer by b, bound as bt at level of the chain in holder.
+func (inj *injector) authorized(t, bt reflect.Type, consumer string, holder Injector, level int, b binding) error {
+	info := BindingInfo{Type: bt, Level: level, Injector: holder, Method: b.method, Meta: copyMeta(b.meta)}
+	if s := b.site; s != nil {
+		info.File, info.Line, info.Registered = s.file, s.line, s.time
+	}
+	r := ResolutionRequest{Type: t, Consumer: consumer, Binding: info}
+	for cur := Injector(inj); cur != nil; {
+		r.Scopes = append(r.Scopes, cur.Label())
+		i, ok := cur.(*injector)
+		if !ok {
+			break
+		}
+		cur = i.loadParent()
+	}
+	if err := inj.opts.authorize(r); err != nil {
+		return &AuthorizationError{Request: r, Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package inject
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func handleOrders(*testRepo) {}
+
+func TestWithAuthorization(t *testing.T) {
+	var requests []ResolutionRequest
+	noLayerSkipping := errors.New("handlers must use services")
+	app := New(WithLabel("app"), WithAuthorization(func(r ResolutionRequest) error {
+		requests = append(requests, r)
+		if r.Binding.Meta["layer"] == "repository" && strings.Contains(r.Consumer, "handle") {
+			return noLayerSkipping
+		}
+		return nil
+	}))
+	app.Map(&testRepo{dsn: "db"}, Meta("layer", "repository"))
+	app.Map("config")
+
+	req := app.Child(WithLabel("request"))
+	_, err := req.Invoke(handleOrders)
+	expect(t, errors.Is(err, ErrDenied), true)
+	expect(t, errors.Is(err, noLayerSkipping), true)
+	var aerr *AuthorizationError
+	expect(t, errors.As(err, &aerr), true)
+	expect(t, aerr.Request.Binding.Level, 1)
+	expect(t, aerr.Request.Binding.Injector, app)
+	expect(t, strings.Join(aerr.Request.Scopes, ","), "request,app")
+	expect(t, err.Error(), "type is denied: *inject.testRepo required by github.com/juanjiTech/inject/v2.handleOrders: handlers must use services")
+
+	// Other consumers and types pass.
+	_, err = req.Invoke(func(r *testRepo, s string) {})
+	expect(t, err, nil)
+	expect(t, len(requests), 3)
+	expect(t, requests[2].Type, Type[string]())
+	expect(t, requests[2].Binding.Meta == nil, true)
+
+	// Checks are composed, the parent's first.
+	strict := req.Child(WithAuthorization(func(r ResolutionRequest) error {
+		return errors.New("child check")
+	}))
+	_, err = strict.Invoke(handleOrders)
+	expect(t, errors.Is(err, noLayerSkipping), true)
+	_, err = strict.Invoke(func(string) {})
+	expect(t, strings.HasSuffix(err.Error(), ": child check"), true)
+}
@@ -0,0 +1,266 @@
+package inject
+
+import (
+	"reflect"
+	"runtime"
+	"sync/atomic"
+	"time"
+)
+
+// bumpGeneration records a change of the bindings of inj, which invalidates
+// the cached lookup results of inj and its children.
+func (inj *injector) bumpGeneration() {
+	atomic.AddUint64(&inj.gen, 1)
+}
+
+// generation returns a number that increases with every change of the
+// bindings or parents in the parent chain of inj. It reports false if the
+// chain contains an Injector not created by this package, whose changes
+// cannot be observed.
+func (inj *injector) generation() (uint64, bool) {
+	var gen uint64
+	for cur := Injector(inj); cur != nil; {
+		i, ok := cur.(*injector)
+		if !ok {
+			return 0, false
+		}
+		gen += atomic.LoadUint64(&i.gen)
+		cur = i.loadParent()
+	}
+	return gen, true
+}
+
+// chainGenerations returns the generations of inj and its parents, starting
+// with inj, each being what generation returns for that injector, so that a
+// lookup walks the chain once. It appends them to buf, and returns nil if the
+// chain contains an Injector not created by this package.
+func (inj *injector) chainGenerations(buf []uint64) []uint64 {
+	gens := buf
+	for cur := Injector(inj); cur != nil; {
+		i, ok := cur.(*injector)
+		if !ok {
+			return nil
+		}
+		gens = append(gens, atomic.LoadUint64(&i.gen))
+		cur = i.loadParent()
+	}
+	for k := len(gens) - 2; k >= 0; k-- {
+		gens[k] += gens[k+1]
+	}
+	return gens
+}
+
+// levelGeneration returns the generation of inj taken by chainGenerations
+// into gens, and the part of it that is the generation of inj itself. It
+// reports false if gens is empty or inj changed since, in which case the
+// lookup does not use the miss cache.
+func (inj *injector) levelGeneration(gens []uint64) (gen, own uint64, ok bool) {
+	if len(gens) == 0 {
+		return 0, 0, false
+	}
+	gen, own = gens[0], gens[0]
+	if len(gens) > 1 {
+		own -= gens[1]
+	}
+	return gen, own, atomic.LoadUint64(&inj.gen) == own
+}
+
+// tail returns the generations of the parents in gens.
+func tail(gens []uint64) []uint64 {
+	if len(gens) == 0 {
+		return nil
+	}
+	return gens[1:]
+}
+
+// binding is a value mapped into an injector together with how it got there.
+// Bindings of provided types have no value but the provider constructing it.
+type binding struct {
+	value    reflect.Value
+	method   string
+	site     *callSite
+	provider *provider
+	// out is the index of the value of the provider.
+	out int
+	// lease is the pool the value is leased from. Bindings of pooled types
+	// have no value until a scope leases one.
+	lease *lease
+	// state is only allocated if a feature needs to track the binding.
+	state *bindingState
+	// meta holds the attributes attached with Meta or WithMeta. It is never
+	// modified once stored.
+	meta map[string]string
+}
+
+// bindingState is the mutable state of a binding, shared by its copies.
+type bindingState struct {
+	resolved uint32 // accessed atomically
+}
+
+// markResolved records that the binding has been resolved, and reports
+// whether it is the first resolution of a tracked binding.
+func (b binding) markResolved() bool {
+	return b.state != nil && atomic.LoadUint32(&b.state.resolved) == 0 &&
+		atomic.CompareAndSwapUint32(&b.state.resolved, 0, 1)
+}
+
+// resolved reports whether the binding has been resolved, which is only known
+// for tracked bindings.
+func (b binding) resolved() bool {
+	return b.state != nil && atomic.LoadUint32(&b.state.resolved) != 0
+}
+
+// bound reports whether the binding can resolve to a value.
+func (b binding) bound() bool {
+	return b.value.IsValid() || b.provider != nil || b.lease != nil
+}
+
+// get returns the value of the binding, constructing it if needed. t is the
+// type being resolved, and chain holds the constructions it is resolved for.
+func (b binding) get(t reflect.Type, chain *building) (reflect.Value, error) {
+	if b.provider != nil {
+		return b.provider.get(t, b.out, chain)
+	}
+	return b.value, nil
+}
+
+// peek returns the value of the binding without constructing it, which is
+// invalid for provided types that have not been resolved yet.
+func (b binding) peek() reflect.Value {
+	if b.provider != nil {
+		return b.provider.peek(b.out)
+	}
+	return b.value
+}
+
+// callSite records where and when a binding was registered, see WithCallSites.
+type callSite struct {
+	file string
+	line int
+	time time.Time
+}
+
+// store binds t to b. The caller must hold the write lock.
+func (inj *injector) store(t reflect.Type, b binding) {
+	if ext := inj.extension(); ext != nil && ext.journaling {
+		prev, existed := inj.values[t]
+		ext.journal = append(ext.journal, journalEntry{typ: t, prev: prev, existed: existed})
+	}
+	if inj.values == nil {
+		inj.values = make(map[reflect.Type]binding)
+	}
+	prev, existed := inj.values[t]
+	if existed && inj.hasOnExpire != 0 {
+		inj.noteExpired(t, prev, b)
+	}
+	if inj.opts.trackUsage || inj.opts.firstUse != nil || inj.opts.freezeResolved {
+		b.state = &bindingState{}
+	}
+	inj.values[t] = b
+	if !existed {
+		inj.indexAdded(t)
+	}
+	inj.bumpGeneration()
+}
+
+// restore binds t to b again, undoing a later store. Unlike store it keeps
+// the state of b and does not journal the change. The caller must hold the
+// write lock.
+func (inj *injector) restore(t reflect.Type, b binding) {
+	_, existed := inj.values[t]
+	inj.values[t] = b
+	if !existed {
+		inj.indexAdded(t)
+	}
+	inj.bumpGeneration()
+}
+
+// remove deletes the binding of t. The caller must hold the write lock.
+func (inj *injector) remove(t reflect.Type) {
+	delete(inj.values, t)
+	for iface, impl := range inj.ifaces {
+		if impl == t {
+			delete(inj.ifaces, iface)
+		}
+	}
+	inj.bumpGeneration()
+}
+
+// indexAdded records t as the implementor of the indexed interfaces that had
+// none. The caller must hold the write lock.
+func (inj *injector) indexAdded(t reflect.Type) {
+	for iface, impl := range inj.ifaces {
+		if impl == nil && t.Implements(iface) {
+			inj.ifaces[iface] = t
+		} else if impl != nil && impl != t && inj.opts.conflicts != nil && t.Implements(iface) {
+			inj.noteAmbiguity(iface, []reflect.Type{impl, t})
+		}
+	}
+}
+
+// implementor returns the type of the binding implementing the interface t,
+// or nil if there is none. Results are kept in an index that is maintained by
+// store and remove, so only the first lookup of an interface scans the
+// bindings.
+func (inj *injector) implementor(t reflect.Type) reflect.Type {
+	if lightMode {
+		return nil
+	}
+	if inj.frozen {
+		return inj.frozenImplementor(t)
+	}
+	inj.mu.RLock()
+	impl, ok := inj.ifaces[t]
+	gen := atomic.LoadUint64(&inj.gen)
+	var ambiguous []Conflict
+	if !ok && inj.opts.conflicts != nil {
+		impl, ambiguous = inj.scanImplementors(t)
+	} else if !ok {
+		for k := range inj.values {
+			if k.Implements(t) {
+				impl = k
+				break
+			}
+		}
+	}
+	inj.mu.RUnlock()
+	if ok {
+		inj.counters.add(countIndexHits)
+		return impl
+	}
+	inj.counters.add(countScans)
+	for _, c := range ambiguous {
+		inj.opts.conflicts(c)
+	}
+
+	inj.mu.Lock()
+	if atomic.LoadUint64(&inj.gen) == gen {
+		if inj.ifaces == nil {
+			inj.ifaces = make(map[reflect.Type]reflect.Type)
+		}
+		inj.ifaces[t] = impl
+	}
+	inj.mu.Unlock()
+	return impl
+}
+
+// callSite returns the call site skip frames above its caller, or nil if call
+// sites are not recorded.
+func (inj *injector) callSite(skip int) *callSite {
+	if !inj.opts.callSites && inj.opts.auditSize <= 0 && inj.opts.conflicts == nil {
+		return nil
+	}
+	_, file, line, _ := runtime.Caller(skip + 1)
+	return &callSite{file: file, line: line, time: time.Now()}
+}
+
+// cacheMiss records that a lookup of t started at generation gen found
+// nothing.
+func (inj *injector) cacheMiss(t reflect.Type, gen uint64) {
+	inj.mu.Lock()
+	if inj.misses == nil {
+		inj.misses = make(map[reflect.Type]uint64)
+	}
+	inj.misses[t] = gen
+	inj.mu.Unlock()
+}
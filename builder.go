@@ -0,0 +1,82 @@
+package inject
+
+import "reflect"
+
+// Builder collects the bindings of an injector whose wiring is static after
+// startup. Build returns an immutable injector, whose lookups take no locks.
+// A Builder is not safe for concurrent use.
+type Builder struct {
+	inj *injector
+}
+
+// NewBuilder returns a Builder for an injector configured by opts.
+func NewBuilder(opts ...Option) *Builder {
+	return &Builder{inj: New(opts...).(*injector)}
+}
+
+// Map maps the values based on their immediate type, see TypeMapper.Map.
+func (b *Builder) Map(values ...interface{}) *Builder {
+	b.inj.Map(values...)
+	return b
+}
+
+// MapTo maps val as the interface type ifacePtr points to, see
+// TypeMapper.MapTo.
+func (b *Builder) MapTo(val interface{}, ifacePtr interface{}) *Builder {
+	b.inj.MapTo(val, ifacePtr)
+	return b
+}
+
+// Set maps typ to val, see TypeMapper.Set.
+func (b *Builder) Set(typ reflect.Type, val reflect.Value) *Builder {
+	b.inj.Set(typ, val)
+	return b
+}
+
+// Provide registers a constructor, see TypeMapper.Provide.
+func (b *Builder) Provide(constructor interface{}, opts ...ProvideOption) error {
+	return b.inj.Provide(constructor, opts...)
+}
+
+// Build returns the injector holding the bindings of the Builder. Its
+// bindings cannot change: Map, MapTo, Set, MapGroup, MapPool, Reset and
+// SetParent panic with ErrImmutable, and Provide and Supply return it. Lazy
+// providers are still constructed on first use, and children created with
+// Child are mutable as usual. The Builder must not be used afterwards.
+func (b *Builder) Build() Injector {
+	inj := b.inj
+	b.inj = nil
+	inj.mu.Lock()
+	// Frozen lookups index interfaces in the extension without locking.
+	inj.extend()
+	inj.frozen = true
+	inj.mu.Unlock()
+	return inj
+}
+
+// mutable reports whether the bindings of inj can change, handling the misuse
+// if they cannot.
+func (inj *injector) mutable() bool {
+	return !inj.frozen || inj.misuse(ErrImmutable)
+}
+
+// frozenImplementor is implementor for frozen injectors, whose bindings are
+// read without locking.
+func (inj *injector) frozenImplementor(t reflect.Type) reflect.Type {
+	ifaces := &inj.extension().frozenIfaces
+	if impl, ok := ifaces.Load(t); ok {
+		inj.counters.add(countIndexHits)
+		impl, _ := impl.(reflect.Type)
+		return impl
+	}
+	inj.counters.add(countScans)
+	var impl reflect.Type
+	for k := range inj.values {
+		if k.Implements(t) {
+			impl = k
+			break
+		}
+	}
+	ifaces.Store(t, impl)
+	return impl
+}
@@ -0,0 +1,68 @@
+//go:build !inject_light
+
+package inject
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestBuilder(t *testing.T) {
+	b := NewBuilder(WithStats())
+	b.Map("dep").MapTo(&greeter{"Jeremy"}, (*fmt.Stringer)(nil))
+	expect(t, b.Provide(func(s string) *testRepo { return &testRepo{dsn: s} }), nil)
+	inj := b.Build()
+
+	_, err := inj.Invoke(func(s string, g fmt.Stringer, r *testRepo) {
+		expect(t, s, "dep")
+		expect(t, g.String(), "Hello, My name isJeremy")
+		expect(t, r.dsn, "dep")
+	})
+	expect(t, err, nil)
+	_, err = inj.Invoke(func(fmt.Stringer, error) {})
+	expect(t, errors.Is(err, ErrValueNotFound), true)
+	_, err = inj.Invoke(func(error) {})
+	expect(t, errors.Is(err, ErrValueNotFound), true)
+	expect(t, inj.Stats().InterfaceScans, uint64(1))
+	expect(t, inj.Stats().IndexHits, uint64(1))
+
+	child := inj.Child()
+	child.Map(1)
+	_, err = child.Invoke(func(int, string) {})
+	expect(t, err, nil)
+
+	expect(t, errors.Is(inj.Provide(func() int { return 0 }), ErrImmutable), true)
+	expect(t, errors.Is(inj.Supply(0), ErrImmutable), true)
+	for name, mutate := range map[string]func(){
+		"Map":       func() { inj.Map(0) },
+		"MapTo":     func() { inj.MapTo(0, (*fmt.Stringer)(nil)) },
+		"Reset":     func() { inj.Reset() },
+		"SetParent": func() { inj.SetParent(New()) },
+		"ResetTo":   func() { inj.ResetTo(0) },
+		"MapGroup":  func() { inj.MapGroup("g", 0) },
+	} {
+		func() {
+			defer func() {
+				err, _ := recover().(error)
+				if !errors.Is(err, ErrImmutable) {
+					t.Errorf("%s: expected ErrImmutable panic, got %v", name, err)
+				}
+			}()
+			mutate()
+		}()
+	}
+}
+
+func BenchmarkBuilder_Value(b *testing.B) {
+	inj := NewBuilder().Map("dep").MapTo(&greeter{}, (*fmt.Stringer)(nil)).Build()
+	fn := func(string, fmt.Stringer) {}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, _ = inj.Invoke(fn)
+		}
+	})
+}
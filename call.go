@@ -0,0 +1,71 @@
+package inject
+
+import "reflect"
+
+// InvokeOption changes a single Invoke or Apply call, see WithValue,
+// WithValueTo and LocalOnly.
+type InvokeOption func(*invocation)
+
+// invocation collects the InvokeOptions of a call.
+type invocation struct {
+	setup []func(TypeMapper)
+	local bool
+}
+
+// WithValue makes val available to a single call under its own type, as if
+// it had been mapped with Map.
+func WithValue(val interface{}) InvokeOption {
+	return func(c *invocation) {
+		c.setup = append(c.setup, func(m TypeMapper) { m.Map(val) })
+	}
+}
+
+// WithValueTo makes val available to a single call under the interface type
+// ifacePtr points to, as if it had been mapped with MapTo.
+func WithValueTo(val interface{}, ifacePtr interface{}) InvokeOption {
+	return func(c *invocation) {
+		c.setup = append(c.setup, func(m TypeMapper) { m.MapTo(val, ifacePtr) })
+	}
+}
+
+// LocalOnly makes a single call resolve its dependencies from the injector
+// itself only, like ValueLocal, e.g. to require request-scoped values to
+// actually be mapped into the request scope.
+func LocalOnly() InvokeOption {
+	return func(c *invocation) {
+		c.local = true
+	}
+}
+
+// InvokeScoped calls f like Invoke in a throwaway child scope, after setup
+// has added the bindings needed by this call only. The scope is discarded
+// once f returns, leaving the Injector untouched.
+func (inj *injector) InvokeScoped(f interface{}, setup func(TypeMapper)) ([]reflect.Value, error) {
+	return inj.Invoke(f, func(c *invocation) {
+		c.setup = append(c.setup, setup)
+	})
+}
+
+func (inj *injector) ValueLocal(t reflect.Type) reflect.Value {
+	scope := &injector{opts: inj.opts, local: true}
+	scope.self.Injector = scope
+	scope.storeParent(inj)
+	val, _ := scope.resolve(t)
+	return val
+}
+
+// callScope returns a child of inj configured by opts. It shares the options
+// of inj and is discarded after the call.
+func (inj *injector) callScope(opts []InvokeOption) *injector {
+	var c invocation
+	for _, opt := range opts {
+		opt(&c)
+	}
+	scope := &injector{opts: inj.opts, local: c.local}
+	scope.self.Injector = scope
+	scope.storeParent(inj)
+	for _, setup := range c.setup {
+		setup(scope)
+	}
+	return scope
+}
@@ -0,0 +1,68 @@
+package inject
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestInvokeWithValue(t *testing.T) {
+	inj := New()
+	inj.Map("base")
+	inj.Map(1)
+
+	var gotS string
+	var gotI int
+	var gotG fmt.Stringer
+	_, err := inj.Invoke(func(s string, i int, g fmt.Stringer) {
+		gotS, gotI, gotG = s, i, g
+	}, WithValue("request"), WithValueTo(&greeter{"Jeremy"}, (*fmt.Stringer)(nil)))
+	expect(t, err, nil)
+	expect(t, gotS, "request")
+	expect(t, gotI, 1)
+	expect(t, gotG.String(), "Hello, My name isJeremy")
+
+	// The injector itself is left untouched.
+	expect(t, inj.Value(Type[string]()).String(), "base")
+	expect(t, inj.Value(Type[fmt.Stringer]()).IsValid(), false)
+}
+
+func TestInjector_InvokeScoped(t *testing.T) {
+	inj := New()
+	inj.Map(1)
+
+	out, err := inj.InvokeScoped(func(s string, i int) string {
+		return fmt.Sprint(s, i)
+	}, func(m TypeMapper) {
+		m.Map("tenant")
+	})
+	expect(t, err, nil)
+	expect(t, out[0].String(), "tenant1")
+	expect(t, inj.Value(Type[string]()).IsValid(), false)
+}
+
+func TestInjector_ValueLocal(t *testing.T) {
+	parent := New()
+	parent.Map("parent", &greeter{"Jeremy"})
+	inj := parent.Child()
+	inj.Map(1)
+
+	expect(t, inj.ValueLocal(Type[int]()).Int(), int64(1))
+	expect(t, inj.ValueLocal(Type[string]()).IsValid(), false)
+	expect(t, inj.ValueLocal(Type[fmt.Stringer]()).IsValid(), false)
+	// Local misses are not cached as misses of the chain.
+	expect(t, inj.Value(Type[string]()).String(), "parent")
+
+	_, err := inj.Invoke(func(string) {}, LocalOnly())
+	expect(t, err != nil, true)
+	_, err = inj.Invoke(func(string, int) {}, LocalOnly(), WithValue("call"))
+	expect(t, err, nil)
+
+	var s struct {
+		S string `inject:""`
+	}
+	expect(t, inj.Apply(&s, LocalOnly()) != nil, true)
+	expect(t, inj.Apply(&s), nil)
+	expect(t, s.S, "parent")
+	expect(t, inj.Apply(&s, WithValue("applied")), nil)
+	expect(t, s.S, "applied")
+}
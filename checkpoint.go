@@ -0,0 +1,55 @@
+package inject
+
+import "reflect"
+
+// Checkpoint is a state of the bindings of an injector, see
+// Injector.Checkpoint.
+//
+// Checkpoints are positions in a journal of the changes made to the injector.
+// Changes are only journaled while checkpoints are in use: ResetTo to the
+// oldest checkpoint, or Reset, ends journaling and invalidates all
+// checkpoints of the injector.
+type Checkpoint int
+
+type journalEntry struct {
+	typ     reflect.Type
+	prev    binding
+	existed bool
+}
+
+func (inj *injector) Checkpoint() Checkpoint {
+	inj.mu.Lock()
+	ext := inj.extend()
+	ext.journaling = true
+	cp := Checkpoint(len(ext.journal))
+	inj.mu.Unlock()
+	return cp
+}
+
+func (inj *injector) ResetTo(cp Checkpoint) {
+	if !inj.mutable() {
+		return
+	}
+	site := inj.callSite(1)
+	inj.mu.Lock()
+	defer inj.mu.Unlock()
+	inj.audit("ResetTo", nil, site)
+
+	ext := inj.extension()
+	if ext == nil || cp < 0 || int(cp) > len(ext.journal) {
+		return
+	}
+	for i := len(ext.journal) - 1; i >= int(cp); i-- {
+		e := ext.journal[i]
+		if e.existed {
+			inj.restore(e.typ, e.prev)
+		} else {
+			inj.remove(e.typ)
+		}
+		ext.journal[i] = journalEntry{}
+	}
+	ext.journal = ext.journal[:cp]
+	if cp == 0 {
+		ext.journaling = false
+	}
+}
@@ -0,0 +1,73 @@
+package inject
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInjector_Checkpoint(t *testing.T) {
+	inj := New()
+	inj.Map("long lived")
+
+	cp := inj.Checkpoint()
+	inj.Map("per request", 1)
+	inner := inj.Checkpoint()
+	inj.Map(&greeter{"Jeremy"}, 2)
+
+	inj.ResetTo(inner)
+	expect(t, inj.Value(reflect.TypeOf(&greeter{})).IsValid(), false)
+	expect(t, inj.Value(reflect.TypeOf(1)).Interface(), 1)
+
+	inj.ResetTo(cp)
+	expect(t, inj.Value(reflect.TypeOf("")).Interface(), "long lived")
+	expect(t, inj.Value(reflect.TypeOf(1)).IsValid(), false)
+
+	// Journaling ended with the reset to the oldest checkpoint.
+	inj.Map(3)
+	inj.ResetTo(cp)
+	expect(t, inj.Value(reflect.TypeOf(1)).Interface(), 3)
+
+	cp = inj.Checkpoint()
+	inj.Map("per request")
+	inj.Reset(KeepParent)
+	inj.ResetTo(cp)
+	expect(t, inj.Value(reflect.TypeOf("")).IsValid(), false)
+}
+
+func TestInjector_ResetToInvalidates(t *testing.T) {
+	inj := New()
+	inj.Map("long lived")
+	child := inj.Child()
+	get := func(s string) string { return s }
+
+	cp := inj.Checkpoint()
+	inj.Map("per request")
+	out, err := inj.InvokeMemo(get)
+	expect(t, err, nil)
+	expect(t, out[0].Interface(), "per request")
+	expect(t, child.Value(reflect.TypeOf(0)).IsValid(), false)
+
+	inj.ResetTo(cp)
+	out, err = inj.InvokeMemo(get)
+	expect(t, err, nil)
+	expect(t, out[0].Interface(), "long lived")
+
+	// Lookups cached by children follow the reset.
+	cp = inj.Checkpoint()
+	inj.Map(0)
+	expect(t, child.Value(reflect.TypeOf(0)).Interface(), 0)
+	inj.ResetTo(cp)
+	expect(t, child.Value(reflect.TypeOf(0)).IsValid(), false)
+}
+
+func BenchmarkInjector_Checkpoint(b *testing.B) {
+	b.ReportAllocs()
+	inj := New()
+	inj.Map("long lived")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cp := inj.Checkpoint()
+		inj.Map("per request", i)
+		inj.ResetTo(cp)
+	}
+}
@@ -0,0 +1,45 @@
+package inject
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWithClock(t *testing.T) {
+	inj := New(WithClock())
+	_, err := inj.Invoke(func(c Clock) {
+		expect(t, c, SystemClock)
+		expect(t, c.Since(c.Now()) < time.Second, true)
+	})
+	expect(t, err, nil)
+	expect(t, New().Value(clockType).IsValid(), false)
+
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	fake := NewFakeClock(start)
+	scope := inj.Child()
+	MapAs[Clock](scope, fake)
+	_, err = scope.Invoke(func(c Clock) {
+		expect(t, c.Now(), start)
+	})
+	expect(t, err, nil)
+}
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewFakeClock(start)
+	soon := c.After(time.Second)
+	later := c.After(time.Minute)
+	now := c.After(0)
+
+	expect(t, <-now, start)
+	c.Advance(2 * time.Second)
+	expect(t, <-soon, start.Add(2*time.Second))
+	expect(t, c.Since(start), 2*time.Second)
+	select {
+	case <-later:
+		t.Fatal("fired early")
+	default:
+	}
+	c.Set(start.Add(time.Hour))
+	expect(t, <-later, start.Add(time.Hour))
+}
@@ -0,0 +1,134 @@
+package inject
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Conflict describes bindings competing for a type, see WithConflicts.
+type Conflict struct {
+	// Type is the contested type: the type bound again for a duplicate, or
+	// the interface implemented by several bound types for an ambiguity.
+	Type reflect.Type
+	// Ambiguous reports whether the conflict is an ambiguity rather than a
+	// duplicate.
+	Ambiguous bool
+	// Bindings are the competing bindings. For a duplicate they are the
+	// replaced binding followed by the new one, for an ambiguity the first
+	// is the binding Type resolves to.
+	Bindings []BindingInfo
+}
+
+func (c Conflict) String() string {
+	var b strings.Builder
+	if c.Ambiguous {
+		fmt.Fprintf(&b, "%v is implemented by %d bindings:", c.Type, len(c.Bindings))
+	} else {
+		fmt.Fprintf(&b, "%v is bound more than once:", c.Type)
+	}
+	for i, info := range c.Bindings {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, " %v by %s", info.Type, info.Method)
+		if info.File != "" {
+			fmt.Fprintf(&b, " at %s:%d", filepath.Base(info.File), info.Line)
+		}
+	}
+	return b.String()
+}
+
+// WithConflicts makes the Injector and its children call report with every
+// type bound again in the same injector, and with every interface resolved
+// from an injector in which several bound types implement it, since only one
+// of them can win. The call site of every registration is captured as with
+// WithCallSites, so that a Conflict tells where each of its bindings came
+// from. report is called after the registration or resolution finished and
+// may use the injector.
+func WithConflicts(report func(Conflict)) Option {
+	return func(o *options) {
+		o.conflicts = report
+	}
+}
+
+// siteInfo returns the BindingInfo of b bound to t in inj.
+func (inj *injector) siteInfo(t reflect.Type, b binding) BindingInfo {
+	info := BindingInfo{Type: t, Injector: inj, Method: b.method, Meta: copyMeta(b.meta)}
+	if b.site != nil {
+		info.File, info.Line, info.Registered = b.site.file, b.site.line, b.site.time
+	}
+	return info
+}
+
+// noteDuplicate queues a Conflict if t is already bound in inj and about to be
+// bound again by method at site. The caller must hold the write lock.
+func (inj *injector) noteDuplicate(method string, t reflect.Type, site *callSite) {
+	prev, ok := inj.values[t]
+	if !ok {
+		return
+	}
+	ext := inj.extend()
+	ext.conflicts = append(ext.conflicts, Conflict{
+		Type:     t,
+		Bindings: []BindingInfo{inj.siteInfo(t, prev), inj.siteInfo(t, binding{method: method, site: site})},
+	})
+}
+
+// noteAmbiguity queues a Conflict for the interface iface implemented by the
+// bound types impls. The caller must hold the write lock.
+func (inj *injector) noteAmbiguity(iface reflect.Type, impls []reflect.Type) {
+	ext := inj.extend()
+	ext.conflicts = append(ext.conflicts, inj.ambiguity(iface, impls))
+}
+
+// ambiguity returns the Conflict for the interface iface implemented by the
+// bound types impls. The caller must hold the lock.
+func (inj *injector) ambiguity(iface reflect.Type, impls []reflect.Type) Conflict {
+	c := Conflict{Type: iface, Ambiguous: true}
+	for _, t := range impls {
+		c.Bindings = append(c.Bindings, inj.siteInfo(t, inj.values[t]))
+	}
+	return c
+}
+
+// scanImplementors returns the first bound type implementing the interface t,
+// and a Conflict if there are several. The caller must hold the lock.
+func (inj *injector) scanImplementors(t reflect.Type) (reflect.Type, []Conflict) {
+	var impls []reflect.Type
+	for k := range inj.values {
+		if k.Implements(t) {
+			impls = append(impls, k)
+		}
+	}
+	if len(impls) == 0 {
+		return nil, nil
+	}
+	rest := impls[1:]
+	sort.Slice(rest, func(i, j int) bool { return rest[i].String() < rest[j].String() })
+	if len(impls) == 1 {
+		return impls[0], nil
+	}
+	return impls[0], []Conflict{inj.ambiguity(t, impls)}
+}
+
+// reportConflicts reports the queued conflicts. The caller must not hold the
+// lock.
+func (inj *injector) reportConflicts() {
+	report := inj.opts.conflicts
+	if report == nil {
+		return
+	}
+	inj.mu.Lock()
+	var conflicts []Conflict
+	if ext := inj.extension(); ext != nil {
+		conflicts = ext.conflicts
+		ext.conflicts = nil
+	}
+	inj.mu.Unlock()
+	for _, c := range conflicts {
+		report(c)
+	}
+}
@@ -0,0 +1,42 @@
+//go:build !inject_light
+
+package inject
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWithConflicts_Ambiguous(t *testing.T) {
+	var conflicts []Conflict
+	inj := New(WithConflicts(func(c Conflict) { conflicts = append(conflicts, c) }))
+	inj.Map(&greeter{"Jeremy"}, &valueStringer{})
+	expect(t, len(conflicts), 0)
+
+	s, err := LoadT[fmt.Stringer](inj)
+	expect(t, err, nil)
+	expect(t, len(conflicts), 1)
+	c := conflicts[0]
+	expect(t, c.Type, Type[fmt.Stringer]())
+	expect(t, c.Ambiguous, true)
+	expect(t, len(c.Bindings), 2)
+	expect(t, c.Bindings[0].Type, reflect.TypeOf(s))
+	expect(t, strings.HasPrefix(c.String(), "fmt.Stringer is implemented by 2 bindings:"), true)
+
+	// Later lookups are served by the index.
+	_, _ = LoadT[fmt.Stringer](inj)
+	expect(t, len(conflicts), 1)
+
+	// A type implementing an indexed interface is reported on registration.
+	inj2 := New(WithConflicts(func(c Conflict) { conflicts = append(conflicts, c) }))
+	inj2.Map(&greeter{"Jeremy"})
+	_, _ = LoadT[fmt.Stringer](inj2)
+	expect(t, len(conflicts), 1)
+	inj2.Map(&valueStringer{})
+	expect(t, len(conflicts), 2)
+	expect(t, conflicts[1].Ambiguous, true)
+	expect(t, conflicts[1].Bindings[0].Type, Type[*greeter]())
+	expect(t, conflicts[1].Bindings[1].Type, Type[*valueStringer]())
+}
@@ -0,0 +1,40 @@
+package inject
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWithConflicts_Duplicate(t *testing.T) {
+	var conflicts []Conflict
+	inj := New(WithConflicts(func(c Conflict) { conflicts = append(conflicts, c) }))
+	inj.Map("first")
+	expect(t, len(conflicts), 0)
+	inj.Map("second")
+	expect(t, len(conflicts), 1)
+
+	c := conflicts[0]
+	expect(t, c.Type, Type[string]())
+	expect(t, c.Ambiguous, false)
+	expect(t, len(c.Bindings), 2)
+	expect(t, c.Bindings[0].Method, "Map")
+	expect(t, filepath.Base(c.Bindings[0].File), "conflict_test.go")
+	expect(t, c.Bindings[1].Line > c.Bindings[0].Line, true)
+	expect(t, strings.HasPrefix(c.String(), "string is bound more than once: string by Map at conflict_test.go:"), true)
+
+	expect(t, inj.Provide(func() string { return "provided" }), nil)
+	expect(t, len(conflicts), 2)
+	expect(t, conflicts[1].Bindings[1].Method, "Provide")
+
+	// A child shadowing a parent binding is not a conflict.
+	inj.Child().Map("shadow")
+	expect(t, len(conflicts), 2)
+}
+
+func TestMissingDependencyError_HintSite(t *testing.T) {
+	inj := New(WithCallSites())
+	inj.Map(&greeter{"Jeremy"})
+	_, err := LoadT[greeter](inj)
+	expect(t, strings.Contains(err.Error(), "(it is bound as a pointer, by Map at conflict_test.go:"), true)
+}
@@ -0,0 +1,59 @@
+package inject
+
+import "reflect"
+
+// Curry partially applies f: every argument of f that can be resolved now is
+// bound, and the returned function takes the remaining ones in their original
+// order, with the same results as f. A variadic argument is never bound.
+// This adapts injected functions to third-party callback signatures.
+//
+// Curry returns ErrNotFunction if f is not a function, and the error of any
+// provider failing to construct an argument. It returns ErrUnsupported in the
+// inject_light build.
+func (inj *injector) Curry(f interface{}) (interface{}, error) {
+	if lightMode {
+		return nil, ErrUnsupported
+	}
+	fv := reflect.ValueOf(f)
+	if fv.Kind() != reflect.Func {
+		return nil, ErrNotFunction
+	}
+	t := fv.Type()
+
+	numIn := t.NumIn()
+	bound := make([]reflect.Value, numIn)
+	var free []int
+	var in []reflect.Type
+	for i := 0; i < numIn; i++ {
+		argType := t.In(i)
+		if !(t.IsVariadic() && i == numIn-1) {
+			val, err := inj.resolve(argType)
+			if err != nil {
+				return nil, err
+			}
+			if val.IsValid() {
+				bound[i] = val
+				continue
+			}
+		}
+		free = append(free, i)
+		in = append(in, argType)
+	}
+
+	out := make([]reflect.Type, t.NumOut())
+	for i := range out {
+		out[i] = t.Out(i)
+	}
+	ft := reflect.FuncOf(in, out, t.IsVariadic())
+	return reflect.MakeFunc(ft, func(args []reflect.Value) []reflect.Value {
+		call := make([]reflect.Value, numIn)
+		copy(call, bound)
+		for i, idx := range free {
+			call[idx] = args[i]
+		}
+		if t.IsVariadic() {
+			return fv.CallSlice(call)
+		}
+		return fv.Call(call)
+	}).Interface(), nil
+}
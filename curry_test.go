@@ -0,0 +1,33 @@
+//go:build !inject_light
+
+package inject
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestInjector_Curry(t *testing.T) {
+	inj := New()
+	inj.Map("dep")
+	inj.Map(&greeter{"Jeremy"})
+
+	f, err := inj.Curry(func(s string, n int, g fmt.Stringer, b bool) string {
+		return fmt.Sprint(s, n, b, g != nil)
+	})
+	expect(t, err, nil)
+	curried, ok := f.(func(int, bool) string)
+	expect(t, ok, true)
+	expect(t, curried(3, true), "dep3 true true")
+
+	f, err = inj.Curry(func(s string, parts ...string) int {
+		return len(s) + len(parts)
+	})
+	expect(t, err, nil)
+	variadic, ok := f.(func(...string) int)
+	expect(t, ok, true)
+	expect(t, variadic("a", "b"), 5)
+
+	_, err = inj.Curry("not a function")
+	expect(t, err, ErrNotFunction)
+}
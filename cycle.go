@@ -0,0 +1,43 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// building is a provider whose constructor is having its arguments resolved,
+// linked to the construction that resolved it in turn. Resolutions pass it
+// down, so that a cycle through constructor arguments is told apart from
+// concurrent constructions without knowing the goroutine.
+type building struct {
+	p    *provider
+	next *building
+}
+
+// cycle returns an error matching ErrCycle if p is under construction in the
+// chain c, which means that its constructor, or a constructor it depends on,
+// resolved t again. Waiting for the construction would deadlock, and
+// constructing again would recurse forever.
+func (c *building) cycle(p *provider, t reflect.Type) error {
+	for ; c != nil; c = c.next {
+		if c.p == p {
+			return cycleError(p, t)
+		}
+	}
+	return nil
+}
+
+func cycleError(p *provider, t reflect.Type) error {
+	err := fmt.Errorf("%w: %v is resolved again while %s constructs it", ErrCycle, t, p.name)
+	return &ProviderError{Type: t, Provider: p.name, Err: err}
+}
+
+// lock locks p.mu, returning an error matching ErrCycle instead if the
+// current goroutine holds it to construct t, which happens if the constructor
+// resolves t through the injector rather than its arguments, see wait.
+func (p *provider) lock(t reflect.Type) error {
+	if p.mu.TryLock() {
+		return nil
+	}
+	return p.wait(t)
+}
@@ -0,0 +1,64 @@
+//go:build !inject_light
+
+package inject
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+type cycleParams struct {
+	In
+	Repo *testRepo
+}
+
+func TestProviderCycle(t *testing.T) {
+	inj := New()
+	expect(t, inj.Provide(func(c *testCache) *testRepo { return &testRepo{} }), nil)
+	expect(t, inj.Provide(func(r *testRepo) *testCache { return &testCache{} }), nil)
+
+	_, err := inj.Invoke(func(*testRepo) {})
+	expect(t, errors.Is(err, ErrCycle), true)
+
+	// Cycles are found whatever the lifetimes, also when resolving on the
+	// goroutine of InvokeContext or through an In struct.
+	proto := New()
+	expect(t, proto.Provide(func(c *testCache) *testRepo { return &testRepo{} }, WithLifetime(Prototype)), nil)
+	expect(t, proto.Provide(func(p cycleParams) *testCache { return &testCache{} }, WithLifetime(Weak)), nil)
+	_, err = proto.Invoke(func(*testRepo) {})
+	expect(t, errors.Is(err, ErrCycle), true)
+	_, err = proto.InvokeContext(context.Background(), func(*testCache) {})
+	expect(t, errors.Is(err, ErrCycle), true)
+
+	// A constructor resolving its own type through the injector.
+	self := New()
+	expect(t, self.Provide(func() *testRepo {
+		_, err := self.Invoke(func(*testRepo) {})
+		expect(t, errors.Is(err, ErrCycle), true)
+		return &testRepo{dsn: "self"}
+	}), nil)
+	expect(t, self.Value(Type[*testRepo]()).Interface().(*testRepo).dsn, "self")
+
+	// Concurrent resolutions are not cycles.
+	shared := New()
+	expect(t, shared.Provide(func() *testRepo { return &testRepo{} }), nil)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := shared.Invoke(func(*testRepo) {})
+			expect(t, err, nil)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestGoroutineID(t *testing.T) {
+	ids := make(chan uint64)
+	go func() { ids <- goroutineID() }()
+	expect(t, goroutineID() != 0, true)
+	expect(t, goroutineID() != <-ids, true)
+}
@@ -0,0 +1,77 @@
+package inject
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"time"
+)
+
+// InvokeContext calls f like Invoke, with ctx mapped as context.Context. Lazy
+// providers constructing a dependency of f are abandoned once ctx is done, so
+// that a hung constructor cannot stall the call indefinitely: the call fails
+// with a *ProviderError matching ctx.Err() and telling what the construction
+// was doing, while the construction goes on in the background and its results
+// are kept for later resolutions.
+func (inj *injector) InvokeContext(ctx context.Context, f interface{}, opts ...InvokeOption) ([]reflect.Value, error) {
+	scope := inj.callScope(opts)
+	defer scope.End()
+	scope.ctx = ctx
+	scope.MapTo(ctx, (*context.Context)(nil))
+	return scope.Invoke(f)
+}
+
+// progress records what a construction is doing. All methods are safe on a
+// nil *progress, which records nothing.
+type progress struct {
+	stage atomic.Value // string
+}
+
+func (p *progress) set(stage string) {
+	if p != nil {
+		p.stage.Store(stage)
+	}
+}
+
+func (p *progress) String() string {
+	if stage, ok := p.stage.Load().(string); ok {
+		return stage
+	}
+	return "waiting for a concurrent construction"
+}
+
+// getContext is get, giving up once ctx is done.
+func (p *provider) getContext(ctx context.Context, t reflect.Type, i int, chain *building) (reflect.Value, error) {
+	if p.lifetime == Singleton && atomic.LoadUint32(&p.done) != 0 {
+		return p.value(i), nil
+	}
+
+	type result struct {
+		val   reflect.Value
+		err   error
+		panic interface{}
+	}
+	start := time.Now()
+	prog := &progress{}
+	done := make(chan result, 1)
+	go func() {
+		var r result
+		defer func() {
+			r.panic = recover()
+			done <- r
+		}()
+		r.val, r.err = p.getWith(t, i, prog, chain)
+	}()
+
+	select {
+	case r := <-done:
+		if r.panic != nil {
+			panic(r.panic)
+		}
+		return r.val, r.err
+	case <-ctx.Done():
+		err := fmt.Errorf("%w after %v %s", ctx.Err(), time.Since(start).Round(time.Millisecond), prog)
+		return reflect.Value{}, &ProviderError{Type: t, Provider: p.name, Err: err}
+	}
+}
@@ -0,0 +1,45 @@
+package inject
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInjector_InvokeContext(t *testing.T) {
+	inj := New()
+	release := make(chan struct{})
+	expect(t, inj.Provide(func() *testRepo {
+		<-release
+		return &testRepo{dsn: "slow"}
+	}), nil)
+	expect(t, inj.Provide(func(r *testRepo) *testCache { return &testCache{size: len(r.dsn)} }), nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := inj.InvokeContext(ctx, func(c *testCache) {})
+	expect(t, errors.Is(err, context.DeadlineExceeded), true)
+	var perr *ProviderError
+	expect(t, errors.As(err, &perr), true)
+	expect(t, perr.Type, Type[*testCache]())
+	expect(t, strings.Contains(err.Error(), "resolving argument 0 (*inject.testRepo)"), true)
+
+	// The construction completes in the background.
+	close(release)
+	var got context.Context
+	_, err = inj.InvokeContext(context.Background(), func(c *testCache, ctx context.Context) {
+		expect(t, c.size, 4)
+		got = ctx
+	})
+	expect(t, err, nil)
+	expect(t, got, context.Background())
+
+	panicking := New()
+	expect(t, panicking.Provide(func() *testRepo { panic("boom") }), nil)
+	defer func() {
+		expect(t, recover(), "boom")
+	}()
+	_, _ = panicking.InvokeContext(context.Background(), func(*testRepo) {})
+}
@@ -0,0 +1,45 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Deny returns an Option forbidding the Injector and its children to resolve
+// the types, directly or as the binding implementing a requested interface,
+// e.g. Deny(Type[*sql.DB]()) for request scopes whose handlers must go through
+// repositories. Resolving a denied type fails with an error matching
+// ErrDenied, even if it is bound. Providers are not affected when they resolve
+// their arguments from the injector they were provided to, so that a
+// repository provided to the application can still use the *sql.DB.
+func Deny(types ...reflect.Type) Option {
+	return func(o *options) {
+		denied := make(map[reflect.Type]bool, len(o.denied)+len(types))
+		for t := range o.denied {
+			denied[t] = true
+		}
+		for _, t := range types {
+			denied[t] = true
+		}
+		o.denied = denied
+	}
+}
+
+// denied returns an error matching ErrDenied if resolving t, bound as bt, is
+// forbidden for consumer in the scope inj.
+func (inj *injector) denied(t, bt reflect.Type, consumer string) error {
+	if !inj.opts.denied[t] && !inj.opts.denied[bt] {
+		return nil
+	}
+	msg := t.String()
+	if bt != t {
+		msg += fmt.Sprintf(" (bound as %v)", bt)
+	}
+	if consumer != "" {
+		msg += " required by " + consumer
+	}
+	if inj.label != "" {
+		msg += fmt.Sprintf(" in scope %q", inj.label)
+	}
+	return fmt.Errorf("%w: %s", ErrDenied, msg)
+}
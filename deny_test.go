@@ -0,0 +1,45 @@
+//go:build !inject_light
+
+package inject
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDeny(t *testing.T) {
+	app := New()
+	db := &testRepo{dsn: "db"}
+	app.Map(db)
+	app.MapTo(&greeter{"Jeremy"}, (*fmt.Stringer)(nil))
+	expect(t, app.Provide(func(r *testRepo) *testCache { return &testCache{size: len(r.dsn)} }), nil)
+
+	request := app.Child(WithLabel("request"), Deny(Type[*testRepo](), Type[*greeter]()))
+	_, err := request.Invoke(func(c *testCache) { expect(t, c.size, 2) })
+	expect(t, err, nil)
+
+	handler := func(*testRepo) { t.Error("denied type injected") }
+	_, err = request.Invoke(handler)
+	expect(t, errors.Is(err, ErrDenied), true)
+	expect(t, err.Error(), "type is denied: *inject.testRepo required by "+targetName(handler)+" in scope \"request\"")
+
+	_, err = request.Child().Invoke(func(*testRepo) {})
+	expect(t, errors.Is(err, ErrDenied), true)
+
+	expect(t, request.Value(Type[fmt.Stringer]()).IsValid(), true)
+	request.MapTo(&greeter{"local"}, (*fmt.Stringer)(nil))
+	_, err = request.Invoke(func(fmt.Stringer) {})
+	expect(t, err, nil)
+	_, err = New(Deny(Type[fmt.Stringer]())).Invoke(func(fmt.Stringer) {})
+	expect(t, errors.Is(err, ErrValueNotFound), true)
+
+	scan := New(Deny(Type[*greeter]()))
+	scan.Map(&greeter{"scanned"})
+	_, err = scan.Invoke(func(fmt.Stringer) {})
+	expect(t, strings.Contains(err.Error(), "fmt.Stringer (bound as *inject.greeter)"), true)
+
+	_, err = app.Invoke(func(*testRepo) {})
+	expect(t, err, nil)
+}
@@ -0,0 +1,96 @@
+package inject
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync/atomic"
+)
+
+// deprecation is a type deprecated with Deprecate.
+type deprecation struct {
+	msg    string
+	warned uint32 // accessed atomically
+}
+
+// Deprecate returns an Option making the Injector and its children log a
+// warning the first time the type t is resolved, with msg, the consumer and
+// the file and line of the call that resolved it, to help migrating off a
+// legacy dependency gradually. The resolution itself succeeds. Warnings are
+// logged through the function given WithLogger, or the standard logger.
+func Deprecate(t reflect.Type, msg string) Option {
+	d := &deprecation{msg: msg}
+	return func(o *options) {
+		deprecated := make(map[reflect.Type]*deprecation, len(o.deprecated)+1)
+		for typ, d := range o.deprecated {
+			deprecated[typ] = d
+		}
+		deprecated[t] = d
+		o.deprecated = deprecated
+	}
+}
+
+// WithLogger makes the Injector and its children log warnings, such as those
+// of Deprecate, with logf instead of the standard logger.
+func WithLogger(logf func(format string, args ...interface{})) Option {
+	return func(o *options) {
+		o.logf = logf
+	}
+}
+
+// logf logs a warning of the injector.
+func (inj *injector) logf(format string, args ...interface{}) {
+	if inj.opts.logf != nil {
+		inj.opts.logf(format, args...)
+		return
+	}
+	log.Printf(format, args...)
+}
+
+// warnDeprecated logs the warning of t, bound as bt, resolved for consumer if
+// either is deprecated and has not been warned about yet.
+func (inj *injector) warnDeprecated(t, bt reflect.Type, consumer string) {
+	typ, d := t, inj.opts.deprecated[t]
+	if d == nil {
+		typ, d = bt, inj.opts.deprecated[bt]
+	}
+	if d == nil || !atomic.CompareAndSwapUint32(&d.warned, 0, 1) {
+		return
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "inject: %v is deprecated: %s", typ, d.msg)
+	if consumer != "" {
+		fmt.Fprintf(&b, " (required by %s)", consumer)
+	}
+	if file, line := externalCaller(); file != "" {
+		fmt.Fprintf(&b, " at %s:%d", filepath.Base(file), line)
+	}
+	inj.logf("%s", b.String())
+}
+
+// packageDir is the directory of the source files of this package.
+var packageDir = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Dir(file)
+}()
+
+// externalCaller returns the file and line of the innermost caller outside of
+// this package, or "" if there is none.
+func externalCaller() (string, int) {
+	pcs := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		f, more := frames.Next()
+		if filepath.Dir(f.File) != packageDir || strings.HasSuffix(f.File, "_test.go") {
+			if !strings.HasPrefix(f.Function, "runtime.") && !strings.HasPrefix(f.Function, "reflect.") {
+				return f.File, f.Line
+			}
+		}
+		if !more {
+			return "", 0
+		}
+	}
+}
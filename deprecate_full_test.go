@@ -0,0 +1,21 @@
+//go:build !inject_light
+
+package inject
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDeprecate_Interface(t *testing.T) {
+	var logged []string
+	inj := New(Deprecate(Type[*greeter](), "use a template"), WithLogger(func(format string, args ...interface{}) {
+		logged = append(logged, fmt.Sprintf(format, args...))
+	}))
+	inj.Map(&greeter{"Jeremy"})
+	_, err := LoadT[fmt.Stringer](inj)
+	expect(t, err, nil)
+	expect(t, len(logged), 1)
+	expect(t, strings.HasPrefix(logged[0], "inject: *inject.greeter is deprecated: use a template (required by LoadT) at deprecate_full_test.go:"), true)
+}
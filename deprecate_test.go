@@ -0,0 +1,27 @@
+package inject
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type legacyClient struct{}
+
+func TestDeprecate(t *testing.T) {
+	var logged []string
+	logf := func(format string, args ...interface{}) {
+		logged = append(logged, fmt.Sprintf(format, args...))
+	}
+	inj := New(Deprecate(Type[*legacyClient](), "use *inject.testRepo"), WithLogger(logf))
+	inj.Map(&legacyClient{})
+
+	_, err := inj.Child().Invoke(func(c *legacyClient) {})
+	expect(t, err, nil)
+	expect(t, len(logged), 1)
+	expect(t, strings.HasPrefix(logged[0], "inject: *inject.legacyClient is deprecated: use *inject.testRepo (required by github.com/juanjiTech/inject/v2.TestDeprecate.func2) at deprecate_test.go:"), true)
+
+	_, err = LoadT[*legacyClient](inj)
+	expect(t, err, nil)
+	expect(t, len(logged), 1)
+}
@@ -0,0 +1,113 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// ChangeKind is the kind of a Change reported by Diff.
+type ChangeKind int
+
+const (
+	// Added means the type is only bound in the second injector.
+	Added ChangeKind = iota + 1
+	// Removed means the type is only bound in the first injector.
+	Removed
+	// Replaced means the type is bound to different values.
+	Replaced
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	case Replaced:
+		return "replaced"
+	}
+	return fmt.Sprintf("ChangeKind(%d)", int(k))
+}
+
+// Change is a difference between the bindings of two injectors.
+type Change struct {
+	Kind ChangeKind
+	Type reflect.Type
+	// Old is the value bound in the first injector, invalid if Added.
+	Old reflect.Value
+	// New is the value bound in the second injector, invalid if Removed.
+	New reflect.Value
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s %v", c.Kind, c.Type)
+}
+
+// Diff reports how the bindings visible from b differ from those visible from
+// a, sorted by type. The bindings of an injector include those of its parents
+// that it does not shadow. Values are the same if they refer to the same
+// storage or, for other kinds, are deeply equal.
+//
+// Bindings of provided types are the same if they are bound by the same
+// Provide call, and their values are only reported once they are constructed.
+// Bindings of pooled types are the same if they are bound by the same MapPool
+// call and, in scopes that leased a value, lease the same one.
+func Diff(a, b Injector) []Change {
+	av, bv := effectiveBindings(a), effectiveBindings(b)
+	var changes []Change
+	for t, old := range av {
+		nv, ok := bv[t]
+		switch {
+		case !ok:
+			changes = append(changes, Change{Kind: Removed, Type: t, Old: old.peek()})
+		case !sameBinding(old, nv):
+			changes = append(changes, Change{Kind: Replaced, Type: t, Old: old.peek(), New: nv.peek()})
+		}
+	}
+	for t, nv := range bv {
+		if _, ok := av[t]; !ok {
+			changes = append(changes, Change{Kind: Added, Type: t, New: nv.peek()})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Type.String() < changes[j].Type.String()
+	})
+	return changes
+}
+
+// effectiveBindings returns the nearest binding of every type in the parent
+// chain of inj.
+func effectiveBindings(inj Injector) map[reflect.Type]binding {
+	bindings := make(map[reflect.Type]binding)
+	for _, e := range chainEntries(inj) {
+		if _, ok := bindings[e.typ]; !ok && e.binding.bound() {
+			bindings[e.typ] = e.binding
+		}
+	}
+	return bindings
+}
+
+func sameBinding(a, b binding) bool {
+	if a.provider != nil || b.provider != nil {
+		return a.provider == b.provider && a.out == b.out
+	}
+	if a.lease != nil || b.lease != nil {
+		return a.lease == b.lease && sameValue(a.value, b.value)
+	}
+	return sameValue(a.value, b.value)
+}
+
+func sameValue(a, b reflect.Value) bool {
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+	if a.Type() != b.Type() {
+		return false
+	}
+	switch a.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return a.Pointer() == b.Pointer()
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
@@ -0,0 +1,61 @@
+package inject
+
+import (
+	"bytes"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	g := &greeter{"Jeremy"}
+	base := func() Injector {
+		inj := New()
+		inj.Map("a dep", g, []string{"a", "b"}).MapTo("another dep", (*specialString)(nil))
+		return inj
+	}
+
+	a, b := base(), base()
+	expect(t, len(Diff(a, b)), 0)
+
+	b.Map(&greeter{"Jeremy"}, 1)
+	b.Set(reflect.TypeOf(""), reflect.ValueOf("a dep"))
+	b.Reset()
+	b.SetParent(base())
+	b.Map(&greeter{"Jeremy"}, 1)
+
+	changes := Diff(a, b)
+	expect(t, len(changes), 2)
+	expect(t, changes[0].Kind, Replaced)
+	expect(t, changes[0].Type, reflect.TypeOf(g))
+	expect(t, changes[0].Old.Interface(), g)
+	expect(t, changes[1].Kind, Added)
+	expect(t, changes[1].String(), "added int")
+
+	changes = Diff(b, New())
+	expect(t, len(changes), 5)
+	expect(t, changes[0].Kind, Removed)
+	expect(t, changes[0].New.IsValid(), false)
+}
+
+func TestDiff_MapPool(t *testing.T) {
+	pool := &sync.Pool{New: func() interface{} { return new(bytes.Buffer) }}
+	a := New()
+	a.MapPool(Type[*bytes.Buffer](), pool)
+	b := a.Child()
+	expect(t, len(Diff(a, b)), 0)
+
+	_, err := b.Invoke(func(*bytes.Buffer) {})
+	expect(t, err, nil)
+	changes := Diff(a, b)
+	expect(t, len(changes), 1)
+	expect(t, changes[0].Kind, Replaced)
+	expect(t, changes[0].Old.IsValid(), false)
+	expect(t, changes[0].New.IsValid(), true)
+
+	other := New()
+	other.MapPool(Type[*bytes.Buffer](), pool)
+	changes = Diff(a, other)
+	expect(t, len(changes), 1)
+	expect(t, changes[0].Kind, Replaced)
+}
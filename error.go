@@ -1,8 +1,122 @@
 package inject
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
 
 var (
 	ErrValueNotFound  = errors.New("value not found")
 	ErrValueCanNotSet = errors.New("value can not set")
+	ErrMethodNotFound = errors.New("method not found")
+
+	ErrNotInterfacePointer = errors.New("value is not a pointer to an interface")
+	ErrInvalidProvider     = errors.New("invalid provider")
+	ErrNotFunction         = errors.New("value is not a function")
+	ErrNilValue            = errors.New("value is nil")
+	ErrCycle               = errors.New("dependency cycle")
+	ErrImmutable           = errors.New("injector is immutable")
+	ErrWiringChanged       = errors.New("wiring changed")
+	ErrProtectedBinding    = errors.New("binding is protected")
+	ErrDenied              = errors.New("type is denied")
+	ErrUnsupported         = errors.New("not supported by the inject_light build")
+	ErrAmbiguous           = errors.New("ambiguous binding")
+	ErrResolvedBinding     = errors.New("binding has been resolved")
 )
+
+// MissingDependencyError is returned when a dependency cannot be resolved. It
+// matches ErrValueNotFound with errors.Is.
+type MissingDependencyError struct {
+	// Type is the requested type.
+	Type reflect.Type
+	// Consumer describes what requested the type: the name of the invoked
+	// function, or the struct field being applied.
+	Consumer string
+	// Chain is the chain of injectors that has been searched, starting with the
+	// one the dependency was requested from.
+	Chain []Injector
+	// Candidates are bound types that are near misses for Type, such as *T
+	// when T was requested.
+	Candidates []reflect.Type
+	// Hints explains for every candidate why it might have been meant.
+	Hints []string
+}
+
+func (e *MissingDependencyError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%v: %v", ErrValueNotFound, e.Type)
+	if e.Consumer != "" {
+		fmt.Fprintf(&b, " (required by %s)", e.Consumer)
+	}
+	if chain := labelChain(e.Chain); chain != "" {
+		fmt.Fprintf(&b, " in [%s]", chain)
+	}
+	for i, c := range e.Candidates {
+		fmt.Fprintf(&b, "; did you mean %v? (%s)", c, e.Hints[i])
+	}
+	return b.String()
+}
+
+func (e *MissingDependencyError) Unwrap() error {
+	return ErrValueNotFound
+}
+
+// missing returns a *MissingDependencyError for t requested by consumer.
+func (inj *injector) missing(t reflect.Type, consumer string) error {
+	err := &MissingDependencyError{Type: t, Consumer: consumer}
+	for cur := Injector(inj); cur != nil; {
+		err.Chain = append(err.Chain, cur)
+		i, ok := cur.(*injector)
+		if !ok {
+			break
+		}
+		cur = i.loadParent()
+	}
+	for _, e := range chainEntries(inj) {
+		if containsType(err.Candidates, e.typ) {
+			continue
+		}
+		if hint := nearMiss(e.typ, e.binding.peek(), t); hint != "" {
+			if site := e.binding.site; site != nil {
+				hint += fmt.Sprintf(", by %s at %s:%d", e.binding.method, filepath.Base(site.file), site.line)
+			}
+			err.Candidates = append(err.Candidates, e.typ)
+			err.Hints = append(err.Hints, hint)
+		}
+	}
+	return err
+}
+
+// nearMiss explains why the binding of bound to v is likely what the requester
+// of t meant, or returns "" if it is not.
+func nearMiss(bound reflect.Type, v reflect.Value, t reflect.Type) string {
+	switch {
+	case bound.Kind() == reflect.Ptr && bound.Elem() == t:
+		return "it is bound as a pointer"
+	case t.Kind() == reflect.Ptr && t.Elem() == bound:
+		return "it is bound as a value"
+	case bound.Kind() == reflect.Interface && v.IsValid() && v.Type() == t:
+		return fmt.Sprintf("a %v is bound to that interface", t)
+	case t.Kind() == reflect.Interface && bound.Kind() != reflect.Ptr &&
+		bound.Kind() != reflect.Interface && reflect.PtrTo(bound).Implements(t):
+		return fmt.Sprintf("only %v implements %v, bind a pointer", reflect.PtrTo(bound), t)
+	case bound.Name() != "" && bound.Name() == t.Name() && bound.PkgPath() != t.PkgPath():
+		return fmt.Sprintf("it has the same name but is from package %q", bound.PkgPath())
+	case bound.Kind() == reflect.Ptr && t.Kind() == reflect.Ptr && bound.Elem().Name() != "" &&
+		bound.Elem().Name() == t.Elem().Name() && bound.Elem().PkgPath() != t.Elem().PkgPath():
+		return fmt.Sprintf("it has the same name but is from package %q", bound.Elem().PkgPath())
+	}
+	return ""
+}
+
+func containsType(types []reflect.Type, t reflect.Type) bool {
+	for _, typ := range types {
+		if typ == t {
+			return true
+		}
+	}
+	return false
+}
@@ -3,7 +3,9 @@ package inject
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -12,4 +14,66 @@ func TestError(t *testing.T) {
 	expect(t, errors.Is(err, ErrValueNotFound), true)
 	err = fmt.Errorf("%w: %v", ErrValueCanNotSet, reflect.TypeOf(""))
 	expect(t, errors.Is(err, ErrValueCanNotSet), true)
+	err = fmt.Errorf("%w: %v", ErrMethodNotFound, reflect.TypeOf(""))
+	expect(t, errors.Is(err, ErrMethodNotFound), true)
+}
+
+func TestMissingDependencyError(t *testing.T) {
+	parent := New()
+	parent.Map(&greeter{"Jeremy"})
+	inj := parent.Child()
+
+	_, err := inj.Invoke(func(g greeter) {})
+	expect(t, errors.Is(err, ErrValueNotFound), true)
+
+	var merr *MissingDependencyError
+	expect(t, errors.As(err, &merr), true)
+	expect(t, merr.Type, reflect.TypeOf(greeter{}))
+	expect(t, strings.HasSuffix(merr.Consumer, "TestMissingDependencyError.func1"), true)
+	expect(t, len(merr.Chain), 2)
+	expect(t, merr.Chain[1], parent)
+	expect(t, len(merr.Candidates), 1)
+	expect(t, merr.Candidates[0], reflect.TypeOf(&greeter{}))
+	expect(t, strings.HasPrefix(err.Error(), "value not found: inject.greeter (required by "), true)
+	expect(t, strings.HasSuffix(err.Error(), "; did you mean *inject.greeter? (it is bound as a pointer)"), true)
+
+	err = inj.Apply(&testStruct{})
+	expect(t, errors.As(err, &merr), true)
+	expect(t, merr.Consumer, "inject.testStruct.Dep1")
+	expect(t, len(merr.Candidates), 0)
+}
+
+type valueStringer struct{}
+
+func (*valueStringer) String() string { return "" }
+
+func TestMissingDependencyError_Hints(t *testing.T) {
+	inj := New()
+	inj.Map(greeter{"Jeremy"}, valueStringer{}, &http.Request{})
+	inj.MapTo(&greeter{"Jeremy"}, (*fmt.Stringer)(nil))
+
+	hints := func(target interface{}) []string {
+		_, err := inj.Invoke(target)
+		var merr *MissingDependencyError
+		expect(t, errors.As(err, &merr), true)
+		return merr.Hints
+	}
+
+	h := hints(func(*greeter) {})
+	expect(t, len(h), 2)
+	expect(t, strings.Join(h, "|"), "a *inject.greeter is bound to that interface|it is bound as a value")
+
+	h = hints(func(error) {})
+	expect(t, len(h), 0)
+
+	type Request struct{}
+	h = hints(func(*Request) {})
+	expect(t, len(h), 1)
+	expect(t, h[0], `it has the same name but is from package "net/http"`)
+
+	type pointerStringer interface{ String() string }
+	inj2 := New()
+	inj2.Map(valueStringer{})
+	_, err := inj2.Invoke(func(pointerStringer) {})
+	expect(t, strings.HasSuffix(err.Error(), "did you mean inject.valueStringer? (only *inject.valueStringer implements inject.pointerStringer, bind a pointer)"), true)
 }
@@ -0,0 +1,93 @@
+package inject
+
+import (
+	"reflect"
+	"sync/atomic"
+)
+
+// expiredValue is a value whose binding has been replaced, queued under the
+// lock to be passed to the OnExpire callbacks once it is released.
+type expiredValue struct {
+	typ reflect.Type
+	old reflect.Value
+}
+
+func (inj *injector) OnExpire(t reflect.Type, fn func(old reflect.Value)) {
+	inj.mu.Lock()
+	ext := inj.extend()
+	if ext.onExpire == nil {
+		ext.onExpire = make(map[reflect.Type][]func(reflect.Value))
+	}
+	ext.onExpire[t] = append(ext.onExpire[t], fn)
+	atomic.StoreUint32(&inj.hasOnExpire, 1)
+	inj.mu.Unlock()
+}
+
+// noteExpired queues the value of prev, the binding of t about to be replaced
+// by b, for the OnExpire callbacks of t. Provided types not constructed yet
+// and pooled types have no value to expire, and neither has a binding
+// replaced by the same value. The caller must hold the write lock.
+func (inj *injector) noteExpired(t reflect.Type, prev, b binding) {
+	ext := inj.extension()
+	if ext == nil || len(ext.onExpire[t]) == 0 || prev.lease != nil {
+		return
+	}
+	old := prev.peek()
+	if !old.IsValid() || b.value.IsValid() && sameValue(old, b.value) {
+		return
+	}
+	ext.expired = append(ext.expired, expiredValue{typ: t, old: old})
+}
+
+// reportExpired passes the queued expired values to the OnExpire callbacks
+// of their types. The caller must not hold the lock.
+func (inj *injector) reportExpired() {
+	if atomic.LoadUint32(&inj.hasOnExpire) == 0 {
+		return
+	}
+	inj.mu.Lock()
+	ext := inj.extension()
+	expired := ext.expired
+	ext.expired = nil
+	fns := make([][]func(reflect.Value), len(expired))
+	for i, e := range expired {
+		fns[i] = ext.onExpire[e.typ]
+	}
+	inj.mu.Unlock()
+	for i, e := range expired {
+		for _, fn := range fns[i] {
+			fn(e.old)
+		}
+	}
+}
+
+// expireDropped passes old, the dropped value of the out-th result of the
+// Weak provider p, to the OnExpire callbacks of its type if p still provides
+// it.
+func (inj *injector) expireDropped(p *provider, out int, old reflect.Value) {
+	if atomic.LoadUint32(&inj.hasOnExpire) == 0 {
+		return
+	}
+	t := p.outs[out].typ
+	inj.mu.RLock()
+	b := inj.values[t]
+	var fns []func(reflect.Value)
+	if ext := inj.extension(); ext != nil {
+		fns = ext.onExpire[t]
+	}
+	inj.mu.RUnlock()
+	if b.provider != p || b.out != out {
+		return
+	}
+	for _, fn := range fns {
+		fn(old)
+	}
+}
+
+// notify runs the hooks queued by a change of the bindings: the conflicts
+// of WithConflicts and the OnExpire callbacks. The caller must not hold the
+// lock.
+func (inj *injector) notify() {
+	inj.reportConflicts()
+	inj.reportExpired()
+}
@@ -0,0 +1,70 @@
+package inject
+
+import (
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+type rotatingConn struct {
+	credentials string
+	closed      bool
+}
+
+func TestOnExpire(t *testing.T) {
+	inj := New()
+	var expired []*rotatingConn
+	connType := Type[*rotatingConn]()
+	inj.OnExpire(connType, func(old reflect.Value) {
+		conn := old.Interface().(*rotatingConn)
+		conn.closed = true
+		expired = append(expired, conn)
+	})
+
+	first := &rotatingConn{credentials: "v1"}
+	inj.Map(first)
+	expect(t, len(expired), 0)
+
+	// Mapping the same value again does not expire it.
+	inj.Map(first)
+	expect(t, len(expired), 0)
+
+	second := &rotatingConn{credentials: "v2"}
+	inj.Map(second)
+	expect(t, len(expired), 1)
+	expect(t, expired[0], first)
+	expect(t, first.closed, true)
+
+	// A provided value only expires once constructed.
+	expect(t, inj.Provide(func() *rotatingConn { return &rotatingConn{credentials: "v3"} }), nil)
+	expect(t, len(expired), 2)
+	expect(t, expired[1], second)
+	expect(t, inj.Provide(func() *rotatingConn { return &rotatingConn{credentials: "v4"} }), nil)
+	expect(t, len(expired), 2)
+	third, _ := LoadT[*rotatingConn](inj)
+	inj.Set(connType, reflect.ValueOf(&rotatingConn{credentials: "v5"}))
+	expect(t, len(expired), 3)
+	expect(t, expired[2], third)
+	expect(t, third.credentials, "v4")
+
+	// Children and other types are not affected.
+	inj.Child().Map(&rotatingConn{}, &rotatingConn{})
+	inj.Map("a", "b")
+	expect(t, len(expired), 3)
+}
+
+func TestOnExpire_Weak(t *testing.T) {
+	inj := New()
+	var expired int32
+	expect(t, inj.Provide(func() *weakArtifact {
+		return &weakArtifact{data: make([]byte, 1<<10)}
+	}, WithLifetime(Weak)), nil)
+	inj.OnExpire(Type[*weakArtifact](), func(old reflect.Value) {
+		if old.Interface().(*weakArtifact) != nil {
+			atomic.AddInt32(&expired, 1)
+		}
+	})
+	_, err := LoadT[*weakArtifact](inj)
+	expect(t, err, nil)
+	expect(t, collectUntil(func() bool { return atomic.LoadInt32(&expired) == 1 }), true)
+}
@@ -0,0 +1,122 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ArgResolution describes how a parameter of a function would be resolved.
+type ArgResolution struct {
+	// Index is the position of the parameter.
+	Index int
+	// Type is the type of the parameter.
+	Type reflect.Type
+	// Found reports whether the parameter can be resolved.
+	Found bool
+	// Bound is the type of the binding satisfying the parameter, which differs
+	// from Type if an interface is satisfied by an implementor.
+	Bound reflect.Type
+	// Level is the position of Injector in the parent chain, 0 being the
+	// injector the function is invoked with.
+	Level int
+	// Injector is the injector holding the binding, or nil for an interface
+	// fallback registered with SetInterfaceFallback.
+	Injector Injector
+	// Method is the method that registered the binding, such as "Map" or
+	// "Provide", or "" for bindings of injectors not created by this package.
+	Method string
+}
+
+func (r ArgResolution) String() string {
+	if !r.Found {
+		return fmt.Sprintf("#%d %v: not found", r.Index, r.Type)
+	}
+	return fmt.Sprintf("#%d %v: %v bound by %s at level %d", r.Index, r.Type, r.Bound, r.Method, r.Level)
+}
+
+func (inj *injector) CanInvoke(f interface{}) error {
+	t := reflect.TypeOf(f)
+	if t == nil || t.Kind() != reflect.Func {
+		return fmt.Errorf("%w: %T", ErrNotFunction, f)
+	}
+	for i := 0; i < t.NumIn(); i++ {
+		argType := t.In(i)
+		if argType.Kind() == reflect.Struct && isInStruct(argType) {
+			if err := inj.canResolveIn(argType); err != nil {
+				return err
+			}
+			continue
+		}
+		if r := inj.locate(argType); !r.Found {
+			return inj.missing(r.Type, targetName(f))
+		}
+	}
+	return nil
+}
+
+func (inj *injector) Explain(f interface{}) []ArgResolution {
+	t := reflect.TypeOf(f)
+	if t == nil || t.Kind() != reflect.Func {
+		return nil
+	}
+	rs := make([]ArgResolution, t.NumIn())
+	for i := range rs {
+		argType := t.In(i)
+		if argType.Kind() == reflect.Struct && isInStruct(argType) {
+			rs[i] = ArgResolution{Type: argType}
+			if inj.canResolveIn(argType) == nil {
+				rs[i].Found, rs[i].Bound, rs[i].Injector, rs[i].Method = true, argType, inj, "In"
+			}
+		} else {
+			rs[i] = inj.locate(argType)
+		}
+		rs[i].Index = i
+	}
+	return rs
+}
+
+// locate finds the binding that would resolve t, following the same rules as
+// resolve but without constructing provided values.
+func (inj *injector) locate(t reflect.Type) ArgResolution {
+	r := ArgResolution{Type: t}
+	for cur := Injector(inj); cur != nil; r.Level++ {
+		i, ok := cur.(*injector)
+		if !ok {
+			if cur.Value(t).IsValid() {
+				r.Found, r.Bound, r.Injector = true, t, cur
+			}
+			return r
+		}
+
+		b := i.slotBinding(t)
+		if !b.bound() {
+			i.mu.RLock()
+			b = i.values[t]
+			i.mu.RUnlock()
+		}
+		bound := t
+		if !b.bound() && t.Kind() == reflect.Interface {
+			if impl := i.implementor(t); impl != nil {
+				i.mu.RLock()
+				b = i.values[impl]
+				i.mu.RUnlock()
+				bound = impl
+			}
+		}
+		if b.bound() {
+			r.Found, r.Bound, r.Injector, r.Method = true, bound, i, b.method
+			return r
+		}
+		cur = i.loadParent()
+	}
+	if alias, ok := inj.opts.aliases[t]; ok {
+		r = inj.locate(alias)
+		r.Type = t
+		return r
+	}
+	r.Level = 0
+	if interfaceFallback(t) != nil {
+		r.Found, r.Bound, r.Method = true, t, "SetInterfaceFallback"
+	}
+	return r
+}
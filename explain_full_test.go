@@ -0,0 +1,36 @@
+//go:build !inject_light
+
+package inject
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestInjector_Explain(t *testing.T) {
+	parent := New()
+	parent.Map(&greeter{"Jeremy"})
+	inj := parent.Child()
+	inj.MapTo("another dep", (*specialString)(nil))
+	expect(t, inj.Provide(func() *testRepo { return &testRepo{} }), nil)
+
+	rs := inj.Explain(func(specialString, fmt.Stringer, *testRepo, int) {})
+	expect(t, len(rs), 4)
+
+	expect(t, rs[0].Found, true)
+	expect(t, rs[0].Level, 0)
+	expect(t, rs[0].Method, "MapTo")
+	expect(t, rs[0].Injector, inj)
+
+	expect(t, rs[1].Found, true)
+	expect(t, rs[1].Bound, Type[*greeter]())
+	expect(t, rs[1].Level, 1)
+	expect(t, rs[1].Injector, parent)
+	expect(t, rs[1].String(), "#1 fmt.Stringer: *inject.greeter bound by Map at level 1")
+
+	expect(t, rs[2].Method, "Provide")
+	expect(t, rs[3].Found, false)
+	expect(t, rs[3].String(), "#3 int: not found")
+
+	expect(t, len(inj.Explain(1)), 0)
+}
@@ -0,0 +1,23 @@
+package inject
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestInjector_CanInvoke(t *testing.T) {
+	inj := New()
+	inj.Map("a dep")
+	calls := 0
+	expect(t, inj.Provide(func() *testRepo { calls++; return &testRepo{} }), nil)
+
+	expect(t, inj.CanInvoke(func(string, *testRepo) {}), nil)
+	expect(t, calls, 0)
+
+	err := inj.Child().CanInvoke(func(string, int) {})
+	var merr *MissingDependencyError
+	expect(t, errors.As(err, &merr), true)
+	expect(t, merr.Type, Type[int]())
+
+	expect(t, errors.Is(inj.CanInvoke("not a function"), ErrNotFunction), true)
+}
@@ -0,0 +1,47 @@
+package inject
+
+import "reflect"
+
+// NewFrom returns a new Injector configured by opts with every type of values
+// bound to its value, as if each had been passed to Set. Invalid values are
+// skipped.
+func NewFrom(values map[reflect.Type]reflect.Value, opts ...Option) Injector {
+	inj := New(opts...).(*injector)
+	site := inj.callSite(1)
+	inj.mu.Lock()
+	for t, v := range values {
+		if v.IsValid() {
+			inj.audit("NewFrom", t, site)
+			inj.store(t, binding{value: v, method: "NewFrom", site: site})
+		}
+	}
+	inj.mu.Unlock()
+	inj.notify()
+	return inj
+}
+
+// Export returns the values bound in the injector, including those given to
+// NewScope of a ScopeTemplate. Provided types are only included once they have
+// been constructed, and pooled types are not included. The result is a copy
+// that can be modified freely.
+func (inj *injector) Export() map[reflect.Type]reflect.Value {
+	inj.mu.RLock()
+	defer inj.mu.RUnlock()
+	values := make(map[reflect.Type]reflect.Value, len(inj.values))
+	for t, b := range inj.values {
+		if b.lease != nil {
+			continue
+		}
+		if v := b.peek(); v.IsValid() {
+			values[t] = v
+		}
+	}
+	if inj.template != nil {
+		for _, t := range inj.template.types {
+			if b := inj.slotBinding(t); b.bound() {
+				values[t] = b.value
+			}
+		}
+	}
+	return values
+}
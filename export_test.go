@@ -0,0 +1,50 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestNewFromExport(t *testing.T) {
+	parent := New()
+	parent.Map(1)
+	inj := parent.Child()
+	inj.Map("dep")
+	inj.MapTo(&greeter{"Jeremy"}, (*fmt.Stringer)(nil))
+	expect(t, inj.Provide(func() *testRepo { return &testRepo{dsn: "db"} }), nil)
+	expect(t, inj.Provide(func() *testCache { return &testCache{} }), nil)
+	_ = inj.Value(Type[*testRepo]())
+
+	values := inj.Export()
+	expect(t, len(values), 3)
+	expect(t, values[Type[string]()].String(), "dep")
+	expect(t, values[Type[*testRepo]()].Interface().(*testRepo).dsn, "db")
+	_, ok := values[Type[int]()]
+	expect(t, ok, false)
+
+	values[Type[string]()] = reflect.ValueOf("changed")
+	expect(t, inj.Value(Type[string]()).String(), "dep")
+
+	restored := NewFrom(values, WithCallSites())
+	_, err := restored.Invoke(func(s string, g fmt.Stringer, r *testRepo) {
+		expect(t, s, "changed")
+		expect(t, g.String(), "Hello, My name isJeremy")
+		expect(t, r.dsn, "db")
+	})
+	expect(t, err, nil)
+	info, _ := restored.OriginOf(Type[string]())
+	expect(t, info.Method, "NewFrom")
+}
+
+func TestExport_ScopeTemplate(t *testing.T) {
+	tmpl := NewScopeTemplate(New(), Type[string](), Type[*greeter](), Type[int]())
+	scope := tmpl.NewScope("from template", &greeter{"Jeremy"}, nil)
+	scope.Map("shadowed", 1.5)
+
+	values := scope.Export()
+	expect(t, len(values), 3)
+	expect(t, values[Type[string]()].String(), "from template")
+	expect(t, values[Type[*greeter]()].Interface().(*greeter).Name, "Jeremy")
+	expect(t, values[Type[float64]()].Float(), 1.5)
+}
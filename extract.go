@@ -0,0 +1,35 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Extract fills every exported field of the struct target points to from the
+// bindings of the injector and its parents, like Apply does for tagged fields
+// but without requiring tags, e.g. to wire a test fixture. Fields may still
+// carry `inject` tag options such as `inject:"group=name"`; a field tagged
+// `inject:"-"` is skipped. It returns ErrValueCanNotSet if target is not a
+// pointer to a struct, and an error for the first field that cannot be
+// resolved.
+func (inj *injector) Extract(target interface{}) error {
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: %T is not a pointer to a struct", ErrValueCanNotSet, target)
+	}
+	v = v.Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("inject")
+		if f.PkgPath != "" || tag == "-" {
+			continue
+		}
+		val, err := inj.resolveField(f.Type, parseTag(tag), t.String()+"."+f.Name, nil)
+		if err != nil {
+			return err
+		}
+		v.Field(i).Set(val)
+	}
+	return nil
+}
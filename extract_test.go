@@ -0,0 +1,37 @@
+package inject
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type fixture struct {
+	Repo    *testRepo
+	Greeter fmt.Stringer
+	Routes  []route `inject:"group=routes"`
+	Skipped int     `inject:"-"`
+	private string
+}
+
+func TestInjector_Extract(t *testing.T) {
+	inj := New()
+	repo := &testRepo{dsn: "db"}
+	expect(t, inj.Provide(func() *testRepo { return repo }), nil)
+	inj.MapTo(&greeter{"Jeremy"}, (*fmt.Stringer)(nil))
+	inj.MapGroup("routes", route("/a"), route("/b"))
+
+	var f fixture
+	expect(t, inj.Child().Extract(&f), nil)
+	expect(t, f.Repo, repo)
+	expect(t, f.Greeter.String(), "Hello, My name isJeremy")
+	expect(t, len(f.Routes), 2)
+	expect(t, f.Skipped, 0)
+
+	var missing struct{ Count int }
+	err := inj.Extract(&missing)
+	expect(t, errors.Is(err, ErrValueNotFound), true)
+
+	expect(t, errors.Is(inj.Extract(f), ErrValueCanNotSet), true)
+	expect(t, errors.Is(inj.Extract((*fixture)(nil)), ErrValueCanNotSet), true)
+}
@@ -0,0 +1,77 @@
+package inject
+
+import (
+	"reflect"
+	"sync"
+)
+
+// fallback is an interface fallback registered with SetInterfaceFallback.
+type fallback struct {
+	fn    func() reflect.Value
+	once  sync.Once
+	value reflect.Value
+}
+
+func (f *fallback) get() reflect.Value {
+	f.once.Do(func() { f.value = f.fn() })
+	return f.value
+}
+
+// interfaceFallbacks maps interface types to their fallbacks.
+var interfaceFallbacks = struct {
+	sync.RWMutex
+	m map[reflect.Type]*fallback
+}{m: make(map[reflect.Type]*fallback)}
+
+// SetInterfaceFallback registers fn as the fallback of the interface type I
+// for every injector: resolving I from an injector whose parent chain neither
+// binds I nor a type implementing it resolves the value returned by fn, e.g.
+// a no-op client, instead of failing. This lets a library depend on an
+// interface without forcing every application to bind it. fn is called once,
+// the first time the fallback is used. Like bound values, fallbacks are
+// subject to Deny and WithAuthorization, and they are not used by ValueLocal
+// and LocalOnly calls. A nil fn removes the fallback. It panics if I is not an
+// interface type.
+func SetInterfaceFallback[I any](fn func() I) {
+	t := Type[I]()
+	if t.Kind() != reflect.Interface {
+		panic("inject: SetInterfaceFallback of non-interface type " + t.String())
+	}
+	interfaceFallbacks.Lock()
+	defer interfaceFallbacks.Unlock()
+	if fn == nil {
+		delete(interfaceFallbacks.m, t)
+		return
+	}
+	interfaceFallbacks.m[t] = &fallback{fn: func() reflect.Value {
+		v := fn()
+		return reflect.ValueOf(&v).Elem()
+	}}
+}
+
+// interfaceFallback returns the fallback of the interface type t, or nil.
+func interfaceFallback(t reflect.Type) *fallback {
+	if t.Kind() != reflect.Interface {
+		return nil
+	}
+	interfaceFallbacks.RLock()
+	defer interfaceFallbacks.RUnlock()
+	return interfaceFallbacks.m[t]
+}
+
+// useFallback returns the value of fb for the resolution of t for consumer,
+// after the Deny and WithAuthorization checks of inj a bound value would go
+// through.
+func (inj *injector) useFallback(t reflect.Type, fb *fallback, consumer string) (reflect.Value, error) {
+	if inj.opts.denied != nil {
+		if err := inj.denied(t, t, consumer); err != nil {
+			return reflect.Value{}, err
+		}
+	}
+	if inj.opts.authorize != nil {
+		if err := inj.authorized(t, t, consumer, nil, 0, binding{method: "SetInterfaceFallback"}); err != nil {
+			return reflect.Value{}, err
+		}
+	}
+	return fb.get(), nil
+}
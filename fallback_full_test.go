@@ -0,0 +1,37 @@
+//go:build !inject_light
+
+package inject
+
+import (
+	"testing"
+)
+
+func TestSetInterfaceFallback(t *testing.T) {
+	calls := 0
+	SetInterfaceFallback(func() metricsClient {
+		calls++
+		return noopMetrics{}
+	})
+	defer SetInterfaceFallback[metricsClient](nil)
+
+	inj := New()
+	m, err := LoadT[metricsClient](inj)
+	expect(t, err, nil)
+	expect(t, m, metricsClient(noopMetrics{}))
+	_, err = inj.Invoke(func(m metricsClient) { m.Count("requests") })
+	expect(t, err, nil)
+	expect(t, inj.CanInvoke(func(metricsClient) {}), nil)
+	expect(t, inj.Explain(func(metricsClient) {})[0].Method, "SetInterfaceFallback")
+	expect(t, calls, 1)
+
+	// A bound implementor wins, also from a parent.
+	counting := &countingMetrics{counts: map[string]int{}}
+	inj.Map(counting)
+	_, err = inj.Child().Invoke(func(m metricsClient) { m.Count("requests") })
+	expect(t, err, nil)
+	expect(t, counting.counts["requests"], 1)
+
+	SetInterfaceFallback[metricsClient](nil)
+	_, err = LoadT[metricsClient](New())
+	expect(t, err != nil, true)
+}
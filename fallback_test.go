@@ -0,0 +1,50 @@
+package inject
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type metricsClient interface {
+	Count(name string)
+}
+
+type noopMetrics struct{}
+
+func (noopMetrics) Count(string) {}
+
+type countingMetrics struct{ counts map[string]int }
+
+func (m *countingMetrics) Count(name string) { m.counts[name]++ }
+
+func TestSetInterfaceFallback_NotInterface(t *testing.T) {
+	defer func() {
+		expect(t, fmt.Sprint(recover()), "inject: SetInterfaceFallback of non-interface type *inject.noopMetrics")
+	}()
+	SetInterfaceFallback(func() *noopMetrics { return nil })
+}
+
+func TestSetInterfaceFallback_Checks(t *testing.T) {
+	SetInterfaceFallback(func() metricsClient { return noopMetrics{} })
+	defer SetInterfaceFallback[metricsClient](nil)
+
+	local := New().Child().ValueLocal(Type[metricsClient]())
+	expect(t, local.IsValid(), false)
+	_, err := New().Child().Invoke(func(metricsClient) {}, LocalOnly())
+	expect(t, errors.Is(err, ErrValueNotFound), true)
+
+	_, err = LoadT[metricsClient](New(Deny(Type[metricsClient]())))
+	expect(t, errors.Is(err, ErrDenied), true)
+
+	var req ResolutionRequest
+	inj := New(WithAuthorization(func(r ResolutionRequest) error {
+		req = r
+		return errors.New("no metrics")
+	}))
+	_, err = LoadT[metricsClient](inj)
+	expect(t, errors.Is(err, ErrDenied), true)
+	expect(t, req.Type, reflect.Type(Type[metricsClient]()))
+	expect(t, req.Binding.Method, "SetInterfaceFallback")
+}
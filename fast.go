@@ -0,0 +1,38 @@
+package inject
+
+import "reflect"
+
+// FastFunc1 wraps a function of one argument as a FastInvoker, so that it is
+// called without reflection: Invoke(FastFunc1[*DB](func(db *DB) { ... })).
+type FastFunc1[A any] func(A)
+
+// Invoke implements FastInvoker.
+func (f FastFunc1[A]) Invoke(args []interface{}) ([]reflect.Value, error) {
+	f(arg[A](args[0]))
+	return nil, nil
+}
+
+// FastFunc2 wraps a function of two arguments as a FastInvoker.
+type FastFunc2[A, B any] func(A, B)
+
+// Invoke implements FastInvoker.
+func (f FastFunc2[A, B]) Invoke(args []interface{}) ([]reflect.Value, error) {
+	f(arg[A](args[0]), arg[B](args[1]))
+	return nil, nil
+}
+
+// FastFunc3 wraps a function of three arguments as a FastInvoker.
+type FastFunc3[A, B, C any] func(A, B, C)
+
+// Invoke implements FastInvoker.
+func (f FastFunc3[A, B, C]) Invoke(args []interface{}) ([]reflect.Value, error) {
+	f(arg[A](args[0]), arg[B](args[1]), arg[C](args[2]))
+	return nil, nil
+}
+
+// arg unpacks a resolved argument. A nil interface value, such as a mapped
+// nil error, unpacks to the zero value of T instead of panicking.
+func arg[T any](v interface{}) T {
+	t, _ := v.(T)
+	return t
+}
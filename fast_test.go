@@ -0,0 +1,60 @@
+package inject
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestFastFunc(t *testing.T) {
+	inj := New()
+	inj.Map("some dependency").MapTo("another dep", (*specialString)(nil))
+	inj.MapTo(&greeter{Name: "Jeremy"}, (*fmt.Stringer)(nil))
+
+	expect(t, IsFastInvoker(FastFunc1[string](nil)), true)
+
+	var got []interface{}
+	_, err := inj.Invoke(FastFunc1[string](func(s string) {
+		got = append(got, s)
+	}))
+	expect(t, err, nil)
+	_, err = inj.Invoke(FastFunc2[string, specialString](func(s string, ss specialString) {
+		got = append(got, s, ss)
+	}))
+	expect(t, err, nil)
+	_, err = inj.Invoke(FastFunc3[string, specialString, fmt.Stringer](func(s string, ss specialString, st fmt.Stringer) {
+		got = append(got, st.String())
+	}))
+	expect(t, err, nil)
+	expect(t, fmt.Sprint(got), "[some dependency some dependency another dep Hello, My name isJeremy]")
+
+	_, err = inj.Invoke(FastFunc1[int](func(int) { t.Error("called with a missing argument") }))
+	expect(t, errors.Is(err, ErrValueNotFound), true)
+}
+
+func TestFastFunc_NilInterface(t *testing.T) {
+	inj := New()
+	inj.Set(Type[error](), reflect.Zero(Type[error]()))
+
+	called := false
+	_, err := inj.Invoke(FastFunc1[error](func(e error) {
+		called = true
+		expect(t, e, nil)
+	}))
+	expect(t, err, nil)
+	expect(t, called, true)
+}
+
+func BenchmarkInjector_FastFunc(b *testing.B) {
+	inj := New()
+	inj.Map("some dependency").MapTo("another dep", (*specialString)(nil))
+
+	fn := FastFunc2[string, specialString](func(d1 string, d2 specialString) {})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = inj.Invoke(fn)
+	}
+}
@@ -0,0 +1,123 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// FederationPolicy chooses the member of a Federation supplying the type t
+// from the members able to resolve it, in the order they were added. There is
+// always more than one.
+type FederationPolicy func(t reflect.Type, members []Injector) (Injector, error)
+
+// PreferFirst is a FederationPolicy choosing the member added first.
+func PreferFirst(t reflect.Type, members []Injector) (Injector, error) {
+	return members[0], nil
+}
+
+// RejectConflicts is a FederationPolicy failing with an error matching
+// ErrAmbiguous, so that shared types must be bound by a single member.
+func RejectConflicts(t reflect.Type, members []Injector) (Injector, error) {
+	return nil, fmt.Errorf("%w: %v is bound by %d members of the federation", ErrAmbiguous, t, len(members))
+}
+
+// Federation resolves types across several independent injectors, e.g. the
+// isolated injectors of the plugins of a host that share some types. Unlike a
+// parent chain, no member takes precedence by position alone: if several
+// members can resolve a type, the FederationPolicy decides. A Federation is
+// safe for concurrent use.
+type Federation struct {
+	policy  FederationPolicy
+	mu      sync.RWMutex
+	members []Injector
+}
+
+// NewFederation returns a Federation of members resolving conflicts with
+// policy, or with PreferFirst if policy is nil.
+func NewFederation(policy FederationPolicy, members ...Injector) *Federation {
+	if policy == nil {
+		policy = PreferFirst
+	}
+	return &Federation{policy: policy, members: members}
+}
+
+// Add adds a member to the federation.
+func (f *Federation) Add(member Injector) {
+	f.mu.Lock()
+	f.members = append(f.members, member)
+	f.mu.Unlock()
+}
+
+// Resolve resolves t from the member supplying it. It returns an error
+// matching ErrValueNotFound if no member can resolve t, the error of the
+// policy, or the error constructing the value.
+func (f *Federation) Resolve(t reflect.Type) (reflect.Value, error) {
+	return f.resolveFor(t, "Federation")
+}
+
+func (f *Federation) resolveFor(t reflect.Type, consumer string) (reflect.Value, error) {
+	f.mu.RLock()
+	var candidates []Injector
+	for _, m := range f.members {
+		if _, ok := m.OriginOf(t); ok {
+			candidates = append(candidates, m)
+		}
+	}
+	f.mu.RUnlock()
+
+	var member Injector
+	switch len(candidates) {
+	case 0:
+		return reflect.Value{}, fmt.Errorf("%w: %v (required by %s)", ErrValueNotFound, t, consumer)
+	case 1:
+		member = candidates[0]
+	default:
+		var err error
+		if member, err = f.policy(t, candidates); err != nil {
+			return reflect.Value{}, err
+		}
+	}
+	if i, ok := member.(*injector); ok {
+		v, err := i.resolveFor(t, consumer)
+		if err == nil && !v.IsValid() {
+			err = i.missing(t, consumer)
+		}
+		return v, err
+	}
+	return member.Value(t), nil
+}
+
+// Invoke calls the function fn with its arguments resolved from the
+// federation, as Resolve does.
+func (f *Federation) Invoke(fn interface{}) ([]reflect.Value, error) {
+	if err := checkFunc(fn); err != nil {
+		return nil, err
+	}
+	fv := reflect.ValueOf(fn)
+	t := fv.Type()
+	consumer := funcName(fv)
+	in := make([]reflect.Value, t.NumIn())
+	for i := range in {
+		v, err := f.resolveFor(t.In(i), consumer)
+		if err != nil {
+			return nil, err
+		}
+		in[i] = v
+	}
+	if t.IsVariadic() {
+		return fv.CallSlice(in), nil
+	}
+	return fv.Call(in), nil
+}
+
+// FederatedT resolves the value of type T from f, e.g.
+// FederatedT[*sql.DB](f).
+func FederatedT[T any](f *Federation) (T, error) {
+	var zero T
+	v, err := f.resolveFor(Type[T](), "FederatedT")
+	if err != nil {
+		return zero, err
+	}
+	return arg[T](v.Interface()), nil
+}
@@ -0,0 +1,39 @@
+//go:build !inject_light
+
+package inject
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestFederation(t *testing.T) {
+	host := New()
+	host.Map("host config")
+	pluginA := New()
+	pluginA.Map(&greeter{"A"}, "plugin A config")
+	pluginB := New()
+	pluginB.Map(&testRepo{dsn: "b"})
+
+	f := NewFederation(nil, host, pluginA)
+	f.Add(pluginB)
+
+	s, err := FederatedT[string](f)
+	expect(t, err, nil)
+	expect(t, s, "host config")
+	g, err := FederatedT[fmt.Stringer](f)
+	expect(t, err, nil)
+	expect(t, g.String(), "Hello, My name isA")
+
+	out, err := f.Invoke(func(r *testRepo, g *greeter) string { return r.dsn + g.Name })
+	expect(t, err, nil)
+	expect(t, out[0].String(), "bA")
+
+	_, err = f.Resolve(Type[int]())
+	expect(t, errors.Is(err, ErrValueNotFound), true)
+	_, err = f.Invoke(func(n int) {})
+	expect(t, errors.Is(err, ErrValueNotFound), true)
+	_, err = f.Invoke("not a function")
+	expect(t, errors.Is(err, ErrNotFunction), true)
+}
@@ -0,0 +1,27 @@
+package inject
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestFederation_Policy(t *testing.T) {
+	a, b := New(), New()
+	a.Map("a")
+	b.Map("b", 1)
+
+	strict := NewFederation(RejectConflicts, a, b)
+	_, err := strict.Resolve(Type[string]())
+	expect(t, errors.Is(err, ErrAmbiguous), true)
+	n, err := FederatedT[int](strict)
+	expect(t, err, nil)
+	expect(t, n, 1)
+
+	preferLast := NewFederation(func(t reflect.Type, members []Injector) (Injector, error) {
+		return members[len(members)-1], nil
+	}, a, b)
+	s, err := FederatedT[string](preferLast)
+	expect(t, err, nil)
+	expect(t, s, "b")
+}
@@ -0,0 +1,34 @@
+package inject
+
+import (
+	"reflect"
+	"sync"
+)
+
+// fieldFilter is a filter given to FieldFilter with the plans made with it.
+type fieldFilter struct {
+	fn    func(reflect.StructField) bool
+	plans sync.Map // reflect.Type to *structPlan
+}
+
+// FieldFilter returns an Option making Apply of the Injector and its children
+// inject the exported fields for which filter returns true, instead of the
+// fields tagged "inject", so that frameworks can follow their own conventions,
+// e.g. injecting every field of an interface type or skipping fields by name.
+// Options of the tag, such as "type=", apply to the fields that have one.
+// filter is called once per field of every struct type.
+func FieldFilter(filter func(reflect.StructField) bool) Option {
+	f := &fieldFilter{fn: filter}
+	return func(o *options) {
+		o.fieldFilter = f
+	}
+}
+
+// plan returns the plan of the struct type t, computing it on first use.
+func (f *fieldFilter) plan(t reflect.Type) *structPlan {
+	if p, ok := f.plans.Load(t); ok {
+		return p.(*structPlan)
+	}
+	p, _ := f.plans.LoadOrStore(t, newFilteredPlan(t, f.fn))
+	return p.(*structPlan)
+}
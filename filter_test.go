@@ -0,0 +1,43 @@
+//go:build !inject_light
+
+package inject
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type filteredStruct struct {
+	Greeter  fmt.Stringer
+	Name     string `inject:""`
+	Optional string
+	NoInject fmt.Stringer
+	private  fmt.Stringer
+}
+
+func TestFieldFilter(t *testing.T) {
+	// Inject every interface field unless its name starts with "No".
+	filter := FieldFilter(func(sf reflect.StructField) bool {
+		return sf.Type.Kind() == reflect.Interface && !strings.HasPrefix(sf.Name, "No")
+	})
+	inj := New(filter)
+	inj.Map(&greeter{"Jeremy"}, "a dep")
+
+	var s filteredStruct
+	expect(t, inj.Child().Apply(&s), nil)
+	expect(t, s.Greeter.String(), "Hello, My name isJeremy")
+	expect(t, s.Name, "")
+	expect(t, s.Optional, "")
+	expect(t, s.NoInject, nil)
+	expect(t, s.private, nil)
+
+	// Without the filter, only tagged fields are injected.
+	var tagged filteredStruct
+	plain := New()
+	plain.Map(&greeter{"Jeremy"}, "a dep")
+	expect(t, plain.Apply(&tagged), nil)
+	expect(t, tagged.Greeter, nil)
+	expect(t, tagged.Name, "a dep")
+}
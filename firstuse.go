@@ -0,0 +1,38 @@
+package inject
+
+import (
+	"reflect"
+	"time"
+)
+
+// FirstUse reports the first resolution of a binding, see WithFirstUse.
+type FirstUse struct {
+	// Type is the requested type.
+	Type reflect.Type
+	// Bound is the bound type, which differs from Type if an interface is
+	// implemented by a binding of a concrete type.
+	Bound reflect.Type
+	// Method is the registration method of the binding, such as "Map" or
+	// "Provide".
+	Method string
+	// Consumer names what requested the type: the invoked function, the
+	// struct field being applied or the constructor of a provided type. It
+	// is empty for direct lookups such as Value, and for requests from
+	// injectors not created WithFirstUse themselves.
+	Consumer string
+	// Level is the position of the injector holding the binding in the parent
+	// chain of the injector the type was requested from.
+	Level int
+	// Time is when the binding was first resolved.
+	Time time.Time
+}
+
+// WithFirstUse makes the Injector and its children call hook the first time
+// each of their bindings is resolved, e.g. to measure cold-start behavior or
+// find dependencies that are only used on rare paths. hook is called
+// synchronously from the resolving goroutine, so it should be fast.
+func WithFirstUse(hook func(FirstUse)) Option {
+	return func(o *options) {
+		o.firstUse = hook
+	}
+}
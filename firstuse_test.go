@@ -0,0 +1,48 @@
+//go:build !inject_light
+
+package inject
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+type firstUseFixture struct {
+	Repo *testRepo `inject:""`
+}
+
+func TestWithFirstUse(t *testing.T) {
+	var mu sync.Mutex
+	var uses []FirstUse
+	inj := New(WithFirstUse(func(u FirstUse) {
+		mu.Lock()
+		uses = append(uses, u)
+		mu.Unlock()
+	}))
+	inj.Map("some dependency")
+	expect(t, inj.Provide(func(s string) *testRepo { return &testRepo{dsn: s} }), nil)
+	inj.Map(&greeter{"Jeremy"})
+	child := inj.Child()
+
+	handler := func(r *testRepo) {}
+	for i := 0; i < 3; i++ {
+		_, err := child.Invoke(handler)
+		expect(t, err, nil)
+	}
+	expect(t, child.Apply(&firstUseFixture{}), nil)
+	expect(t, child.Value(Type[fmt.Stringer]()).IsValid(), true)
+
+	expect(t, len(uses), 3)
+	expect(t, uses[0].Type, Type[*testRepo]())
+	expect(t, uses[0].Method, "Provide")
+	expect(t, uses[0].Consumer, targetName(handler))
+	expect(t, uses[0].Level, 1)
+	expect(t, uses[0].Time.IsZero(), false)
+	expect(t, uses[1].Type, Type[string]())
+	expect(t, uses[1].Consumer, "github.com/juanjiTech/inject/v2.TestWithFirstUse.func2")
+	expect(t, uses[1].Level, 0)
+	expect(t, uses[2].Type, Type[fmt.Stringer]())
+	expect(t, uses[2].Bound, Type[*greeter]())
+	expect(t, uses[2].Consumer, "")
+}
@@ -0,0 +1,35 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// WithFreezeAfterResolve makes the bindings of the Injector and its children
+// immutable once they have been resolved: registering a type again in the
+// injector holding its resolved binding fails with ErrResolvedBinding, so
+// that no consumer gets a different value than the ones before it. Children
+// can still shadow the binding. Like other misuse, the failure panics unless
+// the injector was created WithErrorsOnly, or is returned by the methods
+// returning an error, such as Provide and SetE.
+func WithFreezeAfterResolve() Option {
+	return func(o *options) {
+		o.freezeResolved = true
+	}
+}
+
+// resolvedBinding returns an error matching ErrResolvedBinding if t is bound
+// in inj, inj freezes bindings after their resolution, and the binding of t
+// has been resolved.
+func (inj *injector) resolvedBinding(t reflect.Type) error {
+	if !inj.opts.freezeResolved {
+		return nil
+	}
+	inj.mu.RLock()
+	b, ok := inj.values[t]
+	inj.mu.RUnlock()
+	if ok && b.resolved() {
+		return fmt.Errorf("%w: %v", ErrResolvedBinding, t)
+	}
+	return nil
+}
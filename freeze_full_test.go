@@ -0,0 +1,44 @@
+//go:build !inject_light
+
+package inject
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestWithFreezeAfterResolve(t *testing.T) {
+	inj := New(WithFreezeAfterResolve(), WithErrorsOnly())
+	inj.Map("first")
+	inj.Map("replaced before use")
+	expect(t, inj.Err(), nil)
+
+	s, err := LoadT[string](inj)
+	expect(t, err, nil)
+	expect(t, s, "replaced before use")
+
+	inj.Map("too late")
+	expect(t, errors.Is(inj.Err(), ErrResolvedBinding), true)
+	s, _ = LoadT[string](inj)
+	expect(t, s, "replaced before use")
+	err = inj.SetE(Type[string](), reflect.ValueOf("too late"))
+	expect(t, errors.Is(err, ErrResolvedBinding), true)
+	err = inj.Provide(func() string { return "too late" })
+	expect(t, errors.Is(err, ErrResolvedBinding), true)
+
+	// Resolving an interface freezes its implementation.
+	inj.Map(&greeter{"Jeremy"})
+	_, err = inj.Invoke(func(fmt.Stringer) {})
+	expect(t, err, nil)
+	expect(t, errors.Is(inj.SetE(Type[*greeter](), reflect.ValueOf(&greeter{"Jim"})), ErrResolvedBinding), true)
+
+	// Unresolved types and children are not affected.
+	inj.Map(1)
+	inj.Map(2)
+	child := inj.Child()
+	child.Map("shadow")
+	s, _ = LoadT[string](child)
+	expect(t, s, "shadow")
+}
@@ -0,0 +1,17 @@
+package inject
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWithFreezeAfterResolve_Panics(t *testing.T) {
+	inj := New(WithFreezeAfterResolve())
+	inj.Map("first")
+	_, _ = LoadT[string](inj)
+	defer func() {
+		err, _ := recover().(error)
+		expect(t, errors.Is(err, ErrResolvedBinding), true)
+	}()
+	inj.Map("second")
+}
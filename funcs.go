@@ -0,0 +1,94 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ApplyFuncs fills every nil exported field of function type of the struct
+// val points to, tagged or not. A field is filled with the binding of its
+// function type, or else with the method of the same name and signature of a
+// bound value: a field `GetUser func(id string) (*User, error)` is satisfied
+// by a bound *UserStore with a method GetUser(id string) (*User, error). The
+// nearest level of the parent chain wins, and within a level the first bound
+// type by name. Fields already set are kept, so that they can hold defaults.
+func (inj *injector) ApplyFuncs(val interface{}) error {
+	v := reflect.ValueOf(val)
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct || !v.CanSet() {
+		return nil
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		f := v.Field(i)
+		if sf.PkgPath != "" || sf.Type.Kind() != reflect.Func || !f.IsNil() {
+			continue
+		}
+		fn, err := inj.resolveFunc(sf.Type, sf.Name, t.String()+"."+sf.Name)
+		if err != nil {
+			return err
+		}
+		f.Set(fn)
+	}
+	return nil
+}
+
+// resolveFunc resolves a function of type ft from a binding of ft or a method
+// named name of a bound value.
+func (inj *injector) resolveFunc(ft reflect.Type, name, consumer string) (reflect.Value, error) {
+	v, err := inj.resolveFor(ft, consumer)
+	if v.IsValid() || err != nil {
+		return v, err
+	}
+	if lightMode {
+		return reflect.Value{}, fmt.Errorf("%w: method %s (required by %s)", ErrUnsupported, name, consumer)
+	}
+	for _, e := range chainEntries(inj) {
+		if !e.binding.bound() || !hasMethod(e.typ, name, ft) {
+			continue
+		}
+		v, err := inj.resolveFor(e.typ, consumer)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if v.IsValid() {
+			if v.Kind() == reflect.Interface && v.IsNil() {
+				continue
+			}
+			return v.MethodByName(name), nil
+		}
+	}
+	return reflect.Value{}, inj.missing(ft, consumer)
+}
+
+// hasMethod reports whether values of t have a method named name whose method
+// value is of type ft.
+func hasMethod(t reflect.Type, name string, ft reflect.Type) bool {
+	m, ok := t.MethodByName(name)
+	if !ok {
+		return false
+	}
+	mt := m.Type
+	if t.Kind() == reflect.Interface {
+		return mt == ft
+	}
+	// The method expression takes the receiver as its first argument.
+	if mt.NumIn()-1 != ft.NumIn() || mt.NumOut() != ft.NumOut() || mt.IsVariadic() != ft.IsVariadic() {
+		return false
+	}
+	for i := 0; i < ft.NumIn(); i++ {
+		if mt.In(i+1) != ft.In(i) {
+			return false
+		}
+	}
+	for i := 0; i < ft.NumOut(); i++ {
+		if mt.Out(i) != ft.Out(i) {
+			return false
+		}
+	}
+	return true
+}
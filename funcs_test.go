@@ -0,0 +1,77 @@
+//go:build !inject_light
+
+package inject
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type userStore struct {
+	users map[string]string
+}
+
+func (s *userStore) GetUser(id string) (string, error) {
+	if name, ok := s.users[id]; ok {
+		return name, nil
+	}
+	return "", errors.New("no such user")
+}
+
+func (s *userStore) Count() int { return len(s.users) }
+
+type userFuncs struct {
+	GetUser  func(id string) (string, error)
+	Count    func() int
+	Greeting func() string
+	Now      func() int64
+	internal func() int
+}
+
+func TestInjector_ApplyFuncs(t *testing.T) {
+	inj := New()
+	inj.Map(&userStore{users: map[string]string{"1": "jeremy"}})
+	inj.Map(func() int64 { return 42 })
+	inj.MapTo(&greeter{Name: "Jeremy"}, (*fmt.Stringer)(nil))
+
+	f := userFuncs{Greeting: func() string { return "default" }}
+	err := inj.ApplyFuncs(&f)
+	expect(t, err, nil)
+	name, err := f.GetUser("1")
+	expect(t, name, "jeremy")
+	expect(t, err, nil)
+	expect(t, f.Count(), 1)
+	expect(t, f.Greeting(), "default")
+	expect(t, f.Now(), int64(42))
+	expect(t, f.internal == nil, true)
+
+	var s struct{ String func() string }
+	expect(t, inj.Child().ApplyFuncs(&s), nil)
+	expect(t, s.String(), "Hello, My name isJeremy")
+
+	var missing struct{ Lookup func(string) int }
+	err = inj.ApplyFuncs(&missing)
+	expect(t, errors.Is(err, ErrValueNotFound), true)
+	expect(t, strings.Contains(err.Error(), ".Lookup"), true)
+
+	var wrongSignature struct{ GetUser func(int) (string, error) }
+	expect(t, errors.Is(inj.ApplyFuncs(&wrongSignature), ErrValueNotFound), true)
+
+	expect(t, inj.ApplyFuncs(f), nil)
+}
+
+func TestInjector_ApplyFuncs_Nearest(t *testing.T) {
+	inj := New()
+	inj.Map(&userStore{users: map[string]string{"1": "parent"}})
+	child := inj.Child()
+	child.Map(&userStore{users: map[string]string{"1": "child"}})
+
+	var f userFuncs
+	f.Now = func() int64 { return 0 }
+	f.Greeting = func() string { return "" }
+	expect(t, child.ApplyFuncs(&f), nil)
+	name, _ := f.GetUser("1")
+	expect(t, name, "child")
+}
@@ -0,0 +1,118 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Type returns the reflect.Type of T, which may be an interface type, e.g.
+// Type[io.Writer]() instead of reflect.TypeOf((*io.Writer)(nil)).Elem().
+func Type[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// MapAs maps val as the type I, which is usually an interface, e.g.
+// MapAs[io.Writer](inj, os.Stdout) instead of inj.MapTo(os.Stdout,
+// (*io.Writer)(nil)).
+func MapAs[I any](m TypeMapper, val I) TypeMapper {
+	if inj, ok := m.(*injector); ok {
+		return inj.set(Type[I](), reflect.ValueOf(val), "MapAs", inj.callSite(1))
+	}
+	return m.Set(Type[I](), reflect.ValueOf(val))
+}
+
+// MapG maps val as its type parameter T, e.g. MapG(inj, NewRepo[User](db))
+// for a *Repo[User]. Every instantiation of a generic type is a distinct
+// type, so *Repo[User] and *Repo[Order] are bound and resolved independently.
+// Unlike MapAs, the type is inferred from val.
+func MapG[T any](m TypeMapper, val T) TypeMapper {
+	if inj, ok := m.(*injector); ok {
+		return inj.set(Type[T](), reflect.ValueOf(val), "MapG", inj.callSite(1))
+	}
+	return m.Set(Type[T](), reflect.ValueOf(val))
+}
+
+// GetG returns the value of type T resolved from inj, and reports whether it
+// was found, e.g. repo, ok := GetG[*Repo[User]](inj). Use LoadT to learn why a
+// value could not be resolved.
+func GetG[T any](inj Injector) (T, bool) {
+	v, err := resolveT[T](inj, "GetG")
+	return v, err == nil
+}
+
+// MapAlias returns an Option declaring T an alias of U: a request for T that
+// finds no binding of T in the chain falls back to the binding of U,
+// converted to T. It is meant for strong typedefs such as
+// MapAlias[RequestID, string](), without registering every value twice. It
+// panics if U is not convertible to T.
+func MapAlias[T, U any]() Option {
+	from, to := Type[T](), Type[U]()
+	if !to.ConvertibleTo(from) {
+		panic(fmt.Sprintf("inject: alias %v is not convertible to %v", to, from))
+	}
+	return func(o *options) {
+		aliases := make(map[reflect.Type]reflect.Type, len(o.aliases)+1)
+		for k, v := range o.aliases {
+			aliases[k] = v
+		}
+		aliases[from] = to
+		o.aliases = aliases
+	}
+}
+
+// LoadT resolves the value of type T from inj, e.g. LoadT[*sql.DB](inj). It
+// returns an error if T cannot be resolved or constructing it fails.
+func LoadT[T any](inj Injector) (T, error) {
+	return resolveT[T](inj, "LoadT")
+}
+
+// Resolve2 resolves values of types A and B from inj in one call, e.g. in
+// setup code needing a few dependencies. It returns the first error resolving
+// either of them.
+func Resolve2[A, B any](inj Injector) (A, B, error) {
+	return resolve2[A, B](inj, "Resolve2")
+}
+
+// Resolve3 is Resolve2 for three types.
+func Resolve3[A, B, C any](inj Injector) (A, B, C, error) {
+	var zc C
+	a, b, err := resolve2[A, B](inj, "Resolve3")
+	if err != nil {
+		return a, b, zc, err
+	}
+	c, err := resolveT[C](inj, "Resolve3")
+	return a, b, c, err
+}
+
+// resolve2 resolves values of types A and B from inj on behalf of consumer.
+func resolve2[A, B any](inj Injector, consumer string) (A, B, error) {
+	var zb B
+	a, err := resolveT[A](inj, consumer)
+	if err != nil {
+		return a, zb, err
+	}
+	b, err := resolveT[B](inj, consumer)
+	return a, b, err
+}
+
+// resolveT resolves the value of type T from inj on behalf of consumer.
+func resolveT[T any](inj Injector, consumer string) (T, error) {
+	var zero T
+	t := Type[T]()
+	i, ok := inj.(*injector)
+	if !ok {
+		val := inj.Value(t)
+		if !val.IsValid() {
+			return zero, fmt.Errorf("%w: %v (required by %s)", ErrValueNotFound, t, consumer)
+		}
+		return arg[T](val.Interface()), nil
+	}
+	val, err := i.resolveFor(t, consumer)
+	if err != nil {
+		return zero, err
+	}
+	if !val.IsValid() {
+		return zero, i.missing(t, consumer)
+	}
+	return arg[T](val.Interface()), nil
+}
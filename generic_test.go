@@ -0,0 +1,163 @@
+package inject
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestType(t *testing.T) {
+	expect(t, Type[string](), reflect.TypeOf(""))
+	expect(t, Type[*greeter](), reflect.TypeOf(&greeter{}))
+	expect(t, Type[fmt.Stringer](), InterfaceOf((*fmt.Stringer)(nil)))
+	expect(t, Type[fmt.Stringer]().Kind(), reflect.Interface)
+}
+
+func TestMapAs(t *testing.T) {
+	inj := New()
+	g := &greeter{"Jeremy"}
+	MapAs[fmt.Stringer](inj, g)
+	MapAs[specialString](inj, "a dep")
+
+	expect(t, inj.Value(Type[fmt.Stringer]()).Interface(), g)
+	expect(t, inj.Value(Type[*greeter]()).IsValid(), false)
+
+	_, err := inj.Invoke(func(s fmt.Stringer, d specialString) {
+		expect(t, s, fmt.Stringer(g))
+		expect(t, d, specialString("a dep"))
+	})
+	expect(t, err, nil)
+}
+
+type requestID string
+
+func TestMapAlias(t *testing.T) {
+	parent := New()
+	parent.Map("req-1")
+	inj := parent.Child(MapAlias[requestID, string]())
+
+	var got requestID
+	_, err := inj.Invoke(func(id requestID) { got = id })
+	expect(t, err, nil)
+	expect(t, got, requestID("req-1"))
+	expect(t, inj.CanInvoke(func(requestID) {}), nil)
+
+	inj.Map(requestID("req-2"))
+	_, err = inj.Invoke(func(id requestID) { got = id })
+	expect(t, err, nil)
+	expect(t, got, requestID("req-2"))
+
+	expect(t, parent.Value(Type[requestID]()).IsValid(), false)
+	expect(t, New(MapAlias[requestID, string]()).Value(Type[requestID]()).IsValid(), false)
+
+	defer func() {
+		expect(t, recover() != nil, true)
+	}()
+	MapAlias[requestID, testRepo]()
+}
+
+func TestLoadT(t *testing.T) {
+	inj := New()
+	g := &greeter{"Jeremy"}
+	inj.Map(g)
+	MapAs[fmt.Stringer](inj, g)
+
+	got, err := LoadT[*greeter](inj.Child())
+	expect(t, err, nil)
+	expect(t, got, g)
+	s, err := LoadT[fmt.Stringer](inj)
+	expect(t, err, nil)
+	expect(t, s.String(), "Hello, My name isJeremy")
+
+	_, err = LoadT[int](inj)
+	var missing *MissingDependencyError
+	expect(t, errors.As(err, &missing), true)
+	expect(t, missing.Consumer, "LoadT")
+}
+
+func TestResolve2(t *testing.T) {
+	inj := New()
+	g := &greeter{"Jeremy"}
+	inj.Map(g, "a dep")
+
+	got, s, err := Resolve2[*greeter, string](inj.Child())
+	expect(t, err, nil)
+	expect(t, got, g)
+	expect(t, s, "a dep")
+
+	got, n, err := Resolve2[*greeter, int](inj)
+	expect(t, got, g)
+	expect(t, n, 0)
+	var missing *MissingDependencyError
+	expect(t, errors.As(err, &missing), true)
+	expect(t, missing.Consumer, "Resolve2")
+}
+
+func TestResolve3(t *testing.T) {
+	inj := New()
+	inj.Map(&greeter{"Jeremy"}, "a dep", 42)
+
+	g, s, n, err := Resolve3[*greeter, string, int](inj)
+	expect(t, err, nil)
+	expect(t, g.Name, "Jeremy")
+	expect(t, s, "a dep")
+	expect(t, n, 42)
+
+	_, _, _, err = Resolve3[*testRepo, string, int](inj)
+	var missing *MissingDependencyError
+	expect(t, errors.As(err, &missing), true)
+	expect(t, missing.Consumer, "Resolve3")
+	_, _, _, err = Resolve3[string, int, *testRepo](inj)
+	expect(t, errors.Is(err, ErrValueNotFound), true)
+}
+
+type genericRepo[T any] struct{ items []T }
+
+type genericCache[K comparable, V any] struct{ m map[K]V }
+
+type repoUser struct{ Name string }
+
+type repoOrder struct{ ID int }
+
+func TestMapG(t *testing.T) {
+	inj := New()
+	users := &genericRepo[repoUser]{items: []repoUser{{"Jeremy"}}}
+	orders := &genericRepo[repoOrder]{items: []repoOrder{{1}, {2}}}
+	sessions := genericCache[string, int]{m: map[string]int{"a": 1}}
+	MapG(MapG(inj, users), orders)
+	MapG(inj, sessions)
+
+	u, ok := GetG[*genericRepo[repoUser]](inj)
+	expect(t, ok, true)
+	expect(t, u, users)
+	o, ok := GetG[*genericRepo[repoOrder]](inj.Child())
+	expect(t, ok, true)
+	expect(t, o, orders)
+	_, ok = GetG[*genericRepo[string]](inj)
+	expect(t, ok, false)
+	_, ok = GetG[genericCache[string, string]](inj)
+	expect(t, ok, false)
+
+	_, err := inj.Invoke(func(u *genericRepo[repoUser], o *genericRepo[repoOrder], c genericCache[string, int]) {
+		expect(t, len(u.items)+len(o.items)+c.m["a"], 4)
+	})
+	expect(t, err, nil)
+
+	s := struct {
+		Users  *genericRepo[repoUser]  `inject:""`
+		Orders *genericRepo[repoOrder] `inject:""`
+		Named  *genericRepo[repoUser]  `inject:"type=*github.com/juanjiTech/inject/v2.genericRepo[github.com/juanjiTech/inject/v2.repoUser]"`
+	}{}
+	expect(t, inj.Apply(&s), nil)
+	expect(t, s.Users, users)
+	expect(t, s.Orders, orders)
+	expect(t, s.Named, users)
+
+	v, err := inj.ValueByName("github.com/juanjiTech/inject/v2.*genericRepo[github.com/juanjiTech/inject/v2.repoOrder]")
+	expect(t, err, nil)
+	expect(t, v.Interface(), orders)
+
+	info, _ := inj.OriginOf(Type[*genericRepo[repoUser]]())
+	expect(t, info.Method, "MapG")
+}
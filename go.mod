@@ -1,3 +1,3 @@
 module github.com/juanjiTech/inject/v2
 
-go 1.17
+go 1.18
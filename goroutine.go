@@ -0,0 +1,36 @@
+//go:build !inject_light
+
+package inject
+
+import (
+	"bytes"
+	"reflect"
+	"runtime"
+	"strconv"
+	"sync/atomic"
+)
+
+// wait locks p.mu once the construction in progress is done, unless the
+// current goroutine is the one constructing t. The goroutine is only looked up
+// by constructions and by resolutions finding one in progress, so that
+// resolving constructed values stays cheap.
+func (p *provider) wait(t reflect.Type) error {
+	if g := goroutineID(); g != 0 && atomic.LoadUint64(&p.owner) == g {
+		return cycleError(p, t)
+	}
+	p.mu.Lock()
+	return nil
+}
+
+// goroutineID returns the id of the current goroutine, parsed from the header
+// "goroutine 123 [running]:" of its stack trace.
+func goroutineID() uint64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i >= 0 {
+		b = b[:i]
+	}
+	id, _ := strconv.ParseUint(string(b), 10, 64)
+	return id
+}
@@ -0,0 +1,40 @@
+//go:build inject_light
+
+package inject
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// selfWait is how long a resolution waits for a construction in progress in
+// the inject_light build, which cannot tell whether the construction is the
+// one waiting, as happens if the constructor resolves its own type through
+// the injector.
+var selfWait = 10 * time.Second
+
+// wait locks p.mu once the construction in progress is done, or returns an
+// error matching ErrCycle if it is not done within selfWait.
+func (p *provider) wait(t reflect.Type) error {
+	deadline := time.Now().Add(selfWait)
+	delay := 50 * time.Microsecond
+	for !p.mu.TryLock() {
+		if time.Now().After(deadline) {
+			err := fmt.Errorf("%w: %v is still being constructed by %s after %v, which may resolve it again",
+				ErrCycle, t, p.name, selfWait)
+			return &ProviderError{Type: t, Provider: p.name, Err: err}
+		}
+		time.Sleep(delay)
+		if delay < 10*time.Millisecond {
+			delay *= 2
+		}
+	}
+	return nil
+}
+
+// goroutineID returns 0, as the inject_light build does not parse stack
+// traces.
+func goroutineID() uint64 {
+	return 0
+}
@@ -0,0 +1,252 @@
+package inject
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// GraphDoc generates human-readable documentation of the dependency graph of an
+// Injector: every binding in its parent chain, and which of the registered
+// consumers depend on it.
+type GraphDoc struct {
+	inj       Injector
+	consumers []docConsumer
+}
+
+type docConsumer struct {
+	name string
+	deps []reflect.Type
+}
+
+// NewGraphDoc returns a GraphDoc describing the bindings of inj.
+func NewGraphDoc(inj Injector) *GraphDoc {
+	return &GraphDoc{inj: inj}
+}
+
+// AddConsumer registers target as a consumer of the graph under name. The
+// target is either a function, whose arguments are its dependencies, or a
+// struct (or pointer to one), whose fields tagged with "inject" are. If name is
+// empty, the function name or struct type is used.
+func (d *GraphDoc) AddConsumer(name string, target interface{}) *GraphDoc {
+	if name == "" {
+		name = targetName(target)
+	}
+	d.consumers = append(d.consumers, docConsumer{name: name, deps: targetDependencies(target)})
+	return d
+}
+
+// DocBinding describes a binding in the documentation.
+type DocBinding struct {
+	Type       string
+	Level      int
+	Method     string
+	Origin     string
+	ConsumedBy []string
+	// Meta holds the attributes of the binding, see Meta.
+	Meta map[string]string
+}
+
+// DocConsumer describes a consumer in the documentation.
+type DocConsumer struct {
+	Name         string
+	Dependencies []DocDependency
+}
+
+// DocDependency describes a dependency of a consumer and the binding resolving
+// it, if any.
+type DocDependency struct {
+	Type       string
+	ResolvedBy string
+	Level      int
+}
+
+// Graph returns the documentation data written by WriteMarkdown and WriteHTML.
+func (d *GraphDoc) Graph() ([]DocBinding, []DocConsumer) {
+	entries := chainEntries(d.inj)
+	bindings := make([]DocBinding, len(entries))
+	index := make(map[chainKey]int, len(entries))
+	for i, e := range entries {
+		bindings[i] = DocBinding{
+			Type:   e.typ.String(),
+			Level:  e.level,
+			Method: e.binding.method,
+			Origin: e.binding.site.String(),
+			Meta:   copyMeta(e.binding.meta),
+		}
+		index[chainKey{e.level, e.typ}] = i
+	}
+
+	consumers := make([]DocConsumer, len(d.consumers))
+	for i, c := range d.consumers {
+		consumers[i].Name = c.name
+		for _, dep := range c.deps {
+			dd := DocDependency{Type: dep.String(), Level: -1}
+			if e, ok := lookupEntry(entries, dep); ok {
+				dd.ResolvedBy = e.typ.String()
+				dd.Level = e.level
+				b := &bindings[index[chainKey{e.level, e.typ}]]
+				b.ConsumedBy = append(b.ConsumedBy, c.name)
+			}
+			consumers[i].Dependencies = append(consumers[i].Dependencies, dd)
+		}
+	}
+	return bindings, consumers
+}
+
+// WriteMarkdown writes the documentation as Markdown to w.
+func (d *GraphDoc) WriteMarkdown(w io.Writer) error {
+	bindings, consumers := d.Graph()
+	var b strings.Builder
+	b.WriteString("# Dependency graph\n\n## Bindings\n\n")
+	b.WriteString("| Type | Level | Method | Origin | Consumed by |\n")
+	b.WriteString("| --- | --- | --- | --- | --- |\n")
+	for _, bd := range bindings {
+		fmt.Fprintf(&b, "| `%s` | %d | %s | %s | %s |\n",
+			bd.Type, bd.Level, bd.Method, bd.Origin, strings.Join(bd.ConsumedBy, ", "))
+	}
+	if len(consumers) > 0 {
+		b.WriteString("\n## Consumers\n")
+		for _, c := range consumers {
+			fmt.Fprintf(&b, "\n### %s\n\n", c.Name)
+			if len(c.Dependencies) == 0 {
+				b.WriteString("No dependencies.\n")
+			}
+			for _, dep := range c.Dependencies {
+				if dep.Level < 0 {
+					fmt.Fprintf(&b, "- `%s`: **missing**\n", dep.Type)
+				} else {
+					fmt.Fprintf(&b, "- `%s`: resolved by `%s` (level %d)\n", dep.Type, dep.ResolvedBy, dep.Level)
+				}
+			}
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (s *callSite) String() string {
+	if s == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%s:%d", s.file, s.line)
+}
+
+type chainKey struct {
+	level int
+	typ   reflect.Type
+}
+
+type chainEntry struct {
+	level   int
+	typ     reflect.Type
+	binding binding
+}
+
+// chainEntries returns the bindings of inj and its parents, sorted by type
+// within each level of the parent chain.
+func chainEntries(inj Injector) []chainEntry {
+	var entries []chainEntry
+	level := 0
+	for cur := inj; cur != nil; level++ {
+		i, ok := cur.(*injector)
+		if !ok {
+			break
+		}
+		i.mu.RLock()
+		start := len(entries)
+		for t, b := range i.values {
+			if !i.slotBinding(t).bound() {
+				entries = append(entries, chainEntry{level: level, typ: t, binding: b})
+			}
+		}
+		i.mu.RUnlock()
+		if i.template != nil {
+			for _, t := range i.template.types {
+				if b := i.slotBinding(t); b.bound() {
+					entries = append(entries, chainEntry{level: level, typ: t, binding: b})
+				}
+			}
+		}
+		local := entries[start:]
+		sort.Slice(local, func(a, b int) bool { return local[a].typ.String() < local[b].typ.String() })
+		cur = i.loadParent()
+	}
+	return entries
+}
+
+// lookupEntry finds the entry resolving t the same way Injector.Value does.
+func lookupEntry(entries []chainEntry, t reflect.Type) (chainEntry, bool) {
+	for start := 0; start < len(entries); {
+		end := start
+		for end < len(entries) && entries[end].level == entries[start].level {
+			end++
+		}
+		for _, e := range entries[start:end] {
+			if e.typ == t && e.binding.bound() {
+				return e, true
+			}
+		}
+		if t.Kind() == reflect.Interface {
+			for _, e := range entries[start:end] {
+				if e.typ.Implements(t) {
+					return e, true
+				}
+			}
+		}
+		start = end
+	}
+	return chainEntry{}, false
+}
+
+// targetDependencies returns the argument types of a function, or the types of
+// the fields tagged with "inject" of a struct or pointer to struct.
+func targetDependencies(target interface{}) []reflect.Type {
+	t := reflect.TypeOf(target)
+	if t == nil {
+		return nil
+	}
+	if t.Kind() == reflect.Func {
+		deps := make([]reflect.Type, t.NumIn())
+		for i := range deps {
+			deps[i] = t.In(i)
+		}
+		return deps
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	var deps []reflect.Type
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if _, ok := f.Tag.Lookup("inject"); ok && f.PkgPath == "" {
+			deps = append(deps, f.Type)
+		}
+	}
+	return deps
+}
+
+// targetName returns the name of a function or the type of any other value.
+func targetName(target interface{}) string {
+	return funcName(reflect.ValueOf(target))
+}
+
+// funcName returns the name of the function v, or its type if it is not a
+// named function.
+func funcName(v reflect.Value) string {
+	if v.Kind() == reflect.Func && !v.IsNil() {
+		if fn := runtime.FuncForPC(v.Pointer()); fn != nil {
+			return fn.Name()
+		}
+	}
+	if !v.IsValid() {
+		return "<nil>"
+	}
+	return v.Type().String()
+}
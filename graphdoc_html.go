@@ -0,0 +1,44 @@
+//go:build !inject_light
+
+package inject
+
+import (
+	"html/template"
+	"io"
+)
+
+var graphDocHTML = template.Must(template.New("graph").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Dependency graph</title></head>
+<body>
+<h1>Dependency graph</h1>
+<h2>Bindings</h2>
+<table>
+<tr><th>Type</th><th>Level</th><th>Method</th><th>Origin</th><th>Consumed by</th></tr>
+{{- range .Bindings}}
+<tr><td><code>{{.Type}}</code></td><td>{{.Level}}</td><td>{{.Method}}</td><td>{{.Origin}}</td><td>{{range $i, $c := .ConsumedBy}}{{if $i}}, {{end}}{{$c}}{{end}}</td></tr>
+{{- end}}
+</table>
+{{- if .Consumers}}
+<h2>Consumers</h2>
+{{- range .Consumers}}
+<h3>{{.Name}}</h3>
+<ul>
+{{- range .Dependencies}}
+<li><code>{{.Type}}</code>: {{if lt .Level 0}}<strong>missing</strong>{{else}}resolved by <code>{{.ResolvedBy}}</code> (level {{.Level}}){{end}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- end}}
+</body>
+</html>
+`))
+
+// WriteHTML writes the documentation as a standalone HTML page to w.
+func (d *GraphDoc) WriteHTML(w io.Writer) error {
+	bindings, consumers := d.Graph()
+	return graphDocHTML.Execute(w, struct {
+		Bindings  []DocBinding
+		Consumers []DocConsumer
+	}{bindings, consumers})
+}
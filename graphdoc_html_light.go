@@ -0,0 +1,11 @@
+//go:build inject_light
+
+package inject
+
+import "io"
+
+// WriteHTML fails with ErrUnsupported in the inject_light build, which does
+// not link html/template. WriteMarkdown works in every build.
+func (d *GraphDoc) WriteHTML(w io.Writer) error {
+	return ErrUnsupported
+}
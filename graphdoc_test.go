@@ -0,0 +1,46 @@
+//go:build !inject_light
+
+package inject
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestGraphDoc(t *testing.T) {
+	parent := New()
+	parent.Map("a dep")
+
+	inj := New()
+	inj.SetParent(parent)
+	inj.Map(&greeter{"Jeremy"})
+
+	doc := NewGraphDoc(inj).
+		AddConsumer("handler", func(s string, g fmt.Stringer, i int) {}).
+		AddConsumer("", &testStruct{})
+
+	bindings, consumers := doc.Graph()
+	expect(t, len(bindings), 2)
+	expect(t, bindings[0].Type, "*inject.greeter")
+	expect(t, bindings[0].ConsumedBy[0], "handler")
+	expect(t, bindings[1].Type, "string")
+	expect(t, bindings[1].Level, 1)
+	expect(t, len(bindings[1].ConsumedBy), 2)
+
+	expect(t, len(consumers), 2)
+	expect(t, consumers[0].Dependencies[2].Level, -1)
+	expect(t, consumers[1].Name, "*inject.testStruct")
+	expect(t, len(consumers[1].Dependencies), 2)
+
+	var md bytes.Buffer
+	expect(t, doc.WriteMarkdown(&md), nil)
+	expect(t, strings.Contains(md.String(), "| `*inject.greeter` | 0 | Map | - | handler, *inject.testStruct |"), true)
+	expect(t, strings.Contains(md.String(), "- `int`: **missing**"), true)
+
+	var html bytes.Buffer
+	expect(t, doc.WriteHTML(&html), nil)
+	expect(t, strings.Contains(html.String(), "<code>*inject.greeter</code>"), true)
+	expect(t, strings.Contains(html.String(), "<strong>missing</strong>"), true)
+}
@@ -0,0 +1,132 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// In can be embedded in a struct parameter of an invoked function or a
+// constructor given to Provide, to resolve every exported field of the struct
+// as if it was applied instead of the struct itself. Fields accept the same
+// `inject` tag options as applied structs, such as `inject:"group=name"`.
+type In struct{}
+
+var inType = reflect.TypeOf(In{})
+
+// isInStruct reports whether the struct type t embeds In.
+func isInStruct(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if f := t.Field(i); f.Anonymous && f.Type == inType {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveIn builds a value of the struct type t embedding In by resolving its
+// exported fields for the constructions in chain.
+func (inj *injector) resolveIn(t reflect.Type, chain *building) (reflect.Value, error) {
+	v := reflect.New(t).Elem()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" || f.Type == inType {
+			continue
+		}
+		val, err := inj.resolveField(f.Type, parseTag(f.Tag.Get("inject")), t.String()+"."+f.Name, chain)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v.Field(i).Set(val)
+	}
+	return v, nil
+}
+
+// canResolveIn checks whether every field of the struct type t embedding In
+// can be resolved, without resolving any of them.
+func (inj *injector) canResolveIn(t reflect.Type) error {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" || f.Type == inType {
+			continue
+		}
+		consumer := t.String() + "." + f.Name
+		tag := parseTag(f.Tag.Get("inject"))
+		switch {
+		case tag.group != "":
+		case tag.typeName != "":
+			if inj.typeNamed(tag.typeName) == nil {
+				return fmt.Errorf("%w: %s (required by %s)", ErrValueNotFound, tag.typeName, consumer)
+			}
+		default:
+			if r := inj.locate(f.Type); !r.Found {
+				return inj.missing(f.Type, consumer)
+			}
+		}
+	}
+	return nil
+}
+
+func (inj *injector) MapGroup(group string, values ...interface{}) TypeMapper {
+	if !inj.mutable() {
+		return inj
+	}
+	for _, val := range values {
+		if val != nil && !inj.mustBindable(reflect.TypeOf(val)) {
+			return inj
+		}
+	}
+	site := inj.callSite(1)
+	inj.mu.Lock()
+	// Group values do not replace bindings, so they are audited without a
+	// type.
+	inj.audit("MapGroup", nil, site)
+	ext := inj.extend()
+	if ext.groups == nil {
+		ext.groups = make(map[string][]reflect.Value)
+	}
+	for _, val := range values {
+		ext.groups[group] = append(ext.groups[group], reflect.ValueOf(val))
+	}
+	inj.bumpGeneration()
+	inj.mu.Unlock()
+	return inj
+}
+
+// resolveGroup returns a slice of type ft holding the values of the named
+// group in the injector and its parents, the values of parents first.
+func (inj *injector) resolveGroup(ft reflect.Type, group, consumer string) (reflect.Value, error) {
+	if ft.Kind() != reflect.Slice {
+		return reflect.Value{}, fmt.Errorf("%w: group %q needs a slice, not %v (required by %s)", ErrValueCanNotSet, group, ft, consumer)
+	}
+
+	var chain []*injector
+	for cur := Injector(inj); cur != nil; {
+		in, ok := cur.(*injector)
+		if !ok {
+			break
+		}
+		chain = append(chain, in)
+		cur = in.loadParent()
+	}
+
+	elem := ft.Elem()
+	out := reflect.MakeSlice(ft, 0, 0)
+	for i := len(chain) - 1; i >= 0; i-- {
+		chain[i].mu.RLock()
+		var values []reflect.Value
+		if ext := chain[i].extension(); ext != nil {
+			values = ext.groups[group]
+		}
+		chain[i].mu.RUnlock()
+		for _, val := range values {
+			if !val.IsValid() {
+				return reflect.Value{}, fmt.Errorf("%w: group %q holds an untyped nil (required by %s)", ErrNilValue, group, consumer)
+			}
+			if !val.Type().AssignableTo(elem) {
+				return reflect.Value{}, fmt.Errorf("%w: group %q holds %v, not assignable to %v (required by %s)", ErrValueCanNotSet, group, val.Type(), elem, consumer)
+			}
+			out = reflect.Append(out, val)
+		}
+	}
+	return out, nil
+}
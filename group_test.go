@@ -0,0 +1,89 @@
+package inject
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type middleware func(string) string
+
+func TestApplyGroup(t *testing.T) {
+	inj := New()
+	upper := middleware(func(s string) string { return s + "A" })
+	lower := middleware(func(s string) string { return s + "b" })
+	inj.MapGroup("middleware", upper)
+	child := inj.Child()
+	child.MapGroup("middleware", lower)
+	child.MapGroup("stringers", &greeter{"Jeremy"}, &valueStringer{})
+
+	var s struct {
+		Middleware []middleware   `inject:"group=middleware"`
+		Stringers  []fmt.Stringer `inject:"group=stringers"`
+		Empty      []int          `inject:"group=empty"`
+	}
+	expect(t, child.Apply(&s), nil)
+	expect(t, len(s.Middleware), 2)
+	expect(t, s.Middleware[1](s.Middleware[0]("")), "Ab")
+	expect(t, len(s.Stringers), 2)
+	expect(t, len(s.Empty), 0)
+
+	var bad struct {
+		Stringers []int `inject:"group=stringers"`
+	}
+	expect(t, errors.Is(child.Apply(&bad), ErrValueCanNotSet), true)
+	var notSlice struct {
+		Stringers fmt.Stringer `inject:"group=stringers"`
+	}
+	expect(t, errors.Is(child.Apply(&notSlice), ErrValueCanNotSet), true)
+}
+
+type groupParams struct {
+	In
+	Name       string
+	Middleware []middleware `inject:"group=middleware"`
+	unexported int
+}
+
+func TestInvokeIn(t *testing.T) {
+	inj := New()
+	inj.Map("svc")
+	inj.MapGroup("middleware", middleware(func(s string) string { return s }))
+
+	expect(t, inj.CanInvoke(func(groupParams) {}), nil)
+	expect(t, inj.Explain(func(groupParams) {})[0].Found, true)
+	expect(t, errors.Is(New().CanInvoke(func(groupParams) {}), ErrValueNotFound), true)
+
+	var got groupParams
+	_, err := inj.Invoke(func(p groupParams) { got = p })
+	expect(t, err, nil)
+	expect(t, got.Name, "svc")
+	expect(t, len(got.Middleware), 1)
+
+	expect(t, inj.Provide(func(p groupParams) *testRepo { return &testRepo{dsn: p.Name} }), nil)
+	expect(t, inj.Value(Type[*testRepo]()).Interface().(*testRepo).dsn, "svc")
+
+	_, err = New().Invoke(func(p groupParams) {})
+	expect(t, errors.Is(err, ErrValueNotFound), true)
+}
+
+func TestMapGroupChecks(t *testing.T) {
+	inj := New(WithErrorsOnly())
+	inj.MapGroup("g", nil)
+	var s struct {
+		G []interface{} `inject:"group=g"`
+	}
+	expect(t, errors.Is(inj.Apply(&s), ErrNilValue), true)
+
+	inj.MapProtected("real")
+	child := inj.Child()
+	child.MapGroup("names", "fake")
+	expect(t, errors.Is(child.Err(), ErrProtectedBinding), true)
+
+	calls := 0
+	count := func() int { calls++; return calls }
+	_, _ = inj.InvokeMemo(count)
+	inj.MapGroup("g", 1)
+	out, _ := inj.InvokeMemo(count)
+	expect(t, out[0].Interface(), 2)
+}
@@ -0,0 +1,94 @@
+package inject
+
+import "sync/atomic"
+
+// Handle1 returns a function calling f with its argument resolved from inj.
+// Unlike Invoke, f is called directly, without reflect.Call: the argument is
+// looked up on the first call and reused until a binding of inj or a parent
+// changes, so a Prototype provider constructs it once per change too. If a
+// parent is not created by this package, or inj has options observing or
+// changing resolutions, such as Transform, WithResolveHook, WithRecorder,
+// WithTrace, Deny, Deprecate or WithAuthorization, the argument is looked up
+// on every call.
+func Handle1[A any](inj Injector, f func(A) error) func() error {
+	a := newHandleArg[A](inj, f)
+	return func() error {
+		va, err := a.get()
+		if err != nil {
+			return err
+		}
+		return f(va)
+	}
+}
+
+// Handle2 is Handle1 for functions of two arguments.
+func Handle2[A, B any](inj Injector, f func(A, B) error) func() error {
+	a, b := newHandleArg[A](inj, f), newHandleArg[B](inj, f)
+	return func() error {
+		va, err := a.get()
+		if err != nil {
+			return err
+		}
+		vb, err := b.get()
+		if err != nil {
+			return err
+		}
+		return f(va, vb)
+	}
+}
+
+// Handle3 is Handle1 for functions of three arguments.
+func Handle3[A, B, C any](inj Injector, f func(A, B, C) error) func() error {
+	a, b, c := newHandleArg[A](inj, f), newHandleArg[B](inj, f), newHandleArg[C](inj, f)
+	return func() error {
+		va, err := a.get()
+		if err != nil {
+			return err
+		}
+		vb, err := b.get()
+		if err != nil {
+			return err
+		}
+		vc, err := c.get()
+		if err != nil {
+			return err
+		}
+		return f(va, vb, vc)
+	}
+}
+
+// handleArg resolves an argument of type T of a handler, caching it for the
+// generation of the bindings it was resolved at.
+type handleArg[T any] struct {
+	inj      Injector
+	consumer string
+	// reuse is set if resolutions from inj can be skipped, see plainHits.
+	reuse bool
+	cache atomic.Value // handleEntry[T]
+}
+
+type handleEntry[T any] struct {
+	gen uint64
+	v   T
+}
+
+func newHandleArg[T any](inj Injector, f interface{}) *handleArg[T] {
+	i, ok := inj.(*injector)
+	return &handleArg[T]{inj: inj, consumer: targetName(f), reuse: ok && i.plainHits()}
+}
+
+func (a *handleArg[T]) get() (T, error) {
+	inj, ok := a.inj.(*injector)
+	if !ok || !a.reuse {
+		return resolveT[T](a.inj, a.consumer)
+	}
+	gen, cacheable := inj.generation()
+	if e, ok := a.cache.Load().(handleEntry[T]); ok && cacheable && e.gen == gen {
+		return e.v, nil
+	}
+	v, err := resolveT[T](inj, a.consumer)
+	if err == nil && cacheable {
+		a.cache.Store(handleEntry[T]{gen: gen, v: v})
+	}
+	return v, err
+}
@@ -0,0 +1,114 @@
+package inject
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestHandle(t *testing.T) {
+	inj := New()
+	inj.Map("some dependency").MapTo("another dep", (*specialString)(nil))
+	inj.MapTo(&greeter{Name: "Jeremy"}, (*fmt.Stringer)(nil))
+
+	var got []interface{}
+	h1 := Handle1(inj, func(s string) error {
+		got = append(got, s)
+		return nil
+	})
+	h2 := Handle2(inj, func(s string, ss specialString) error {
+		got = append(got, ss)
+		return nil
+	})
+	h3 := Handle3(inj, func(s string, ss specialString, st fmt.Stringer) error {
+		got = append(got, st.String())
+		return errors.New("handler failed")
+	})
+	expect(t, h1(), nil)
+	expect(t, h2(), nil)
+	expect(t, h3().Error(), "handler failed")
+	expect(t, fmt.Sprint(got), "[some dependency another dep Hello, My name isJeremy]")
+
+	inj.Map("changed")
+	expect(t, h1(), nil)
+	expect(t, got[len(got)-1], "changed")
+
+	err := Handle1(inj, func(int) error { return nil })()
+	expect(t, errors.Is(err, ErrValueNotFound), true)
+}
+
+func TestHandle_Cached(t *testing.T) {
+	inj := New()
+	calls := 0
+	expect(t, inj.Provide(func() string {
+		calls++
+		return "constructed"
+	}, WithLifetime(Prototype)), nil)
+
+	h := Handle1(inj, func(s string) error { return nil })
+	for i := 0; i < 3; i++ {
+		expect(t, h(), nil)
+	}
+	expect(t, calls, 1)
+
+	inj.Map(1)
+	expect(t, h(), nil)
+	expect(t, calls, 2)
+}
+
+func TestHandle_Observed(t *testing.T) {
+	hooked := 0
+	inj := New(WithResolveHook(func(reflect.Type, reflect.Value, error) { hooked++ }))
+	inj.Map("value")
+	h := Handle1(inj, func(string) error { return nil })
+	expect(t, h(), nil)
+	expect(t, h(), nil)
+	expect(t, hooked, 2)
+
+	inj = New(Transform(Type[string](), func(v reflect.Value) reflect.Value { return reflect.ValueOf(v.String() + "!") }))
+	inj.Map("value")
+	var got []string
+	h = Handle1(inj, func(s string) error {
+		got = append(got, s)
+		return nil
+	})
+	expect(t, h(), nil)
+	expect(t, h(), nil)
+	expect(t, fmt.Sprint(got), "[value! value!]")
+
+	denied := false
+	inj = New(WithAuthorization(func(ResolutionRequest) error {
+		if denied {
+			return errors.New("denied")
+		}
+		return nil
+	}))
+	inj.Map("value")
+	h = Handle1(inj, func(string) error { return nil })
+	expect(t, h(), nil)
+	denied = true
+	expect(t, errors.Is(h(), ErrDenied), true)
+}
+
+func TestHandle_ProviderError(t *testing.T) {
+	inj := New()
+	expect(t, inj.Provide(func() (string, error) { return "", errors.New("boom") }), nil)
+
+	err := Handle1(inj, func(string) error { return nil })()
+	var pe *ProviderError
+	expect(t, errors.As(err, &pe), true)
+}
+
+func BenchmarkHandle2(b *testing.B) {
+	inj := New()
+	inj.Map("some dependency").MapTo("another dep", (*specialString)(nil))
+
+	h := Handle2(inj, func(d1 string, d2 specialString) error { return nil })
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = h()
+	}
+}
@@ -0,0 +1,130 @@
+package inject
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// HealthChecker can be implemented by mapped values to take part in
+// Injector.Healthy.
+type HealthChecker interface {
+	// CheckHealth returns a non-nil error if the value is not healthy.
+	CheckHealth(ctx context.Context) error
+}
+
+// HealthCheck is an explicitly registered health check.
+type HealthCheck func(ctx context.Context) error
+
+var healthCheckerType = reflect.TypeOf((*HealthChecker)(nil)).Elem()
+
+func (inj *injector) AddHealthCheck(name string, check HealthCheck) {
+	inj.mu.Lock()
+	ext := inj.extend()
+	if ext.checks == nil {
+		ext.checks = make(map[string]HealthCheck)
+	}
+	ext.checks[name] = check
+	inj.mu.Unlock()
+}
+
+// Healthy runs the health checks of the injector and its parents concurrently.
+// Mapped values implementing HealthChecker are reported under the string
+// representation of the type they are mapped to, explicit checks under their
+// name. A value mapped more than once is only checked once, under the first
+// type name in sort order. Nil values, such as those bound by MapNil, are not
+// checked.
+func (inj *injector) Healthy(ctx context.Context) map[string]error {
+	checks := make(map[string]HealthCheck)
+	seen := make(map[interface{}]bool)
+	for cur := Injector(inj); cur != nil; {
+		i, ok := cur.(*injector)
+		if !ok {
+			for name, err := range cur.Healthy(ctx) {
+				if _, ok := checks[name]; !ok {
+					err := err
+					checks[name] = func(context.Context) error { return err }
+				}
+			}
+			break
+		}
+		i.collectHealthChecks(checks, seen)
+		cur = i.loadParent()
+	}
+
+	result := make(map[string]error, len(checks))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check HealthCheck) {
+			defer wg.Done()
+			err := check(ctx)
+			mu.Lock()
+			result[name] = err
+			mu.Unlock()
+		}(name, check)
+	}
+	wg.Wait()
+	return result
+}
+
+// collectHealthChecks adds the checks of inj not shadowed by a child to checks.
+func (inj *injector) collectHealthChecks(checks map[string]HealthCheck, seen map[interface{}]bool) {
+	inj.mu.RLock()
+	defer inj.mu.RUnlock()
+
+	if ext := inj.extension(); ext != nil {
+		for name, check := range ext.checks {
+			if _, ok := checks[name]; !ok {
+				checks[name] = check
+			}
+		}
+	}
+	names := make([]string, 0, len(inj.values))
+	values := make(map[string]reflect.Value, len(inj.values))
+	for t, b := range inj.values {
+		if v := b.peek(); v.IsValid() && v.Type().Implements(healthCheckerType) && !isNil(v) {
+			names = append(names, t.String())
+			values[t.String()] = v
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		v := values[name]
+		if _, ok := checks[name]; ok {
+			continue
+		}
+		hc := v.Interface().(HealthChecker)
+		if key, ok := identity(v); ok {
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		checks[name] = hc.CheckHealth
+	}
+}
+
+// isNil reports whether v is a nil interface or pointer.
+func isNil(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
+
+// identity returns a key identifying the value v refers to, if v is of a kind
+// that refers to shared storage.
+func identity(v reflect.Value) (interface{}, bool) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return v.Pointer(), true
+	}
+	if v.Type().Comparable() {
+		return v.Interface(), true
+	}
+	return nil, false
+}
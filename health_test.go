@@ -0,0 +1,56 @@
+package inject
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type healthyDB struct {
+	err error
+}
+
+func (db *healthyDB) CheckHealth(ctx context.Context) error {
+	return db.err
+}
+
+func TestInjector_Healthy(t *testing.T) {
+	failure := errors.New("connection refused")
+
+	parent := New()
+	parent.Map(&healthyDB{err: failure})
+	parent.AddHealthCheck("queue", func(ctx context.Context) error { return nil })
+
+	inj := New()
+	inj.SetParent(parent)
+	db := &healthyDB{}
+	inj.Map(db).MapTo(db, (*HealthChecker)(nil))
+	inj.Map("not a checker")
+	inj.AddHealthCheck("cache", func(ctx context.Context) error { return failure })
+
+	result := inj.Healthy(context.Background())
+	expect(t, len(result), 3)
+	expect(t, result["*inject.healthyDB"], nil)
+	expect(t, result["queue"], nil)
+	expect(t, result["cache"], failure)
+
+	result = parent.Healthy(context.Background())
+	expect(t, len(result), 2)
+	expect(t, result["*inject.healthyDB"], failure)
+}
+
+type checkedStore interface {
+	HealthChecker
+	Get(key string) string
+}
+
+func TestInjector_Healthy_Nil(t *testing.T) {
+	inj := New()
+	MapNil[checkedStore](inj)
+	inj.Map((*healthyDB)(nil))
+	inj.AddHealthCheck("queue", func(ctx context.Context) error { return nil })
+
+	result := inj.Healthy(context.Background())
+	expect(t, len(result), 1)
+	expect(t, result["queue"], nil)
+}
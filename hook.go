@@ -0,0 +1,22 @@
+package inject
+
+import "reflect"
+
+// WithResolveHook makes the Injector and its children call hook after every
+// resolution of a type with the value found, which is invalid if there is
+// none, and the error constructing it, e.g. to feed metrics. Hooks given to an
+// injector and to its parent are all called, the parent's first. hook is
+// called on the resolving goroutine, so it must be quick and safe for
+// concurrent use.
+func WithResolveHook(hook func(t reflect.Type, v reflect.Value, err error)) Option {
+	return func(o *options) {
+		if prev := o.resolveHook; prev != nil {
+			o.resolveHook = func(t reflect.Type, v reflect.Value, err error) {
+				prev(t, v, err)
+				hook(t, v, err)
+			}
+			return
+		}
+		o.resolveHook = hook
+	}
+}
@@ -0,0 +1,35 @@
+package inject
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWithResolveHook(t *testing.T) {
+	var calls []string
+	hook := func(prefix string) func(reflect.Type, reflect.Value, error) {
+		return func(typ reflect.Type, v reflect.Value, err error) {
+			calls = append(calls, prefix+typ.String())
+			if err != nil {
+				calls = append(calls, prefix+"error")
+			} else if !v.IsValid() {
+				calls = append(calls, prefix+"miss")
+			}
+		}
+	}
+	inj := New(WithResolveHook(hook("app ")))
+	inj.Map("a dep")
+	expect(t, inj.Provide(func() (*testRepo, error) { return nil, errors.New("failed") }), nil)
+
+	_, _ = LoadT[string](inj)
+	_, _ = LoadT[int](inj)
+	_, _ = LoadT[*testRepo](inj)
+	expect(t, strings.Join(calls, ", "), "app string, app int, app miss, app *inject.testRepo, app error")
+
+	calls = nil
+	child := inj.Child(WithResolveHook(hook("child ")))
+	_, _ = LoadT[string](child)
+	expect(t, strings.Join(calls, ", "), "app string, child string")
+}
@@ -3,9 +3,15 @@
 package inject
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"reflect"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
+	"unsafe"
 )
 
 // Injector represents an interface for mapping and injecting dependencies into
@@ -14,19 +20,115 @@ type Injector interface {
 	Applicator
 	Invoker
 	TypeMapper
-	// Reset will reset Injector, include reset mapped value and parent
-	Reset()
+	// Reset will reset Injector, include reset mapped value and parent. The
+	// storage of the injector is retained for reuse, and options such as
+	// KeepParent change what is reset.
+	Reset(...ResetOption)
 	// SetParent sets the parent of the injector. If the injector cannot find a
 	// dependency in its Type map it will check its parent before returning an
-	// error.
+	// error. It is safe to call concurrently with lookups, which see either
+	// the old or the new parent.
 	SetParent(Injector) Injector
+	// Child returns a new Injector with the injector as its parent and the same
+	// options, changed by opts. The child allocates storage only once something
+	// is mapped into it, which makes short-lived scopes cheap.
+	Child(opts ...Option) Injector
+	// AddHealthCheck registers check to be run by Healthy under name.
+	AddHealthCheck(name string, check HealthCheck)
+	// Healthy runs every registered health check and the CheckHealth method of
+	// every mapped value implementing HealthChecker, including those of its
+	// parents. It returns the result of each check, nil for healthy ones.
+	Healthy(ctx context.Context) map[string]error
+	// BuildAll constructs every singleton provider of the injector and runs
+	// its readiness probe, retrying with backoff until all of them are ready
+	// or ctx is done.
+	BuildAll(ctx context.Context, opts ...BuildOption) error
+	// WriteReport writes a human-readable summary of every binding of the
+	// injector and its parents to w, e.g. to confirm the wiring at startup.
+	WriteReport(w io.Writer) error
+	// Checkpoint returns a Checkpoint of the bindings of the injector, to which
+	// they can be restored with ResetTo.
+	Checkpoint() Checkpoint
+	// ResetTo undoes every Map, MapTo and Set of the injector since cp was
+	// taken, restoring the bindings they replaced. It does not affect parents.
+	ResetTo(cp Checkpoint)
+	// UnusedBindings returns the types bound in the injector, not its parents,
+	// that have never been resolved, sorted by name. Only bindings made while
+	// WithUsageTracking is enabled are tracked.
+	UnusedBindings() []reflect.Type
+	// OnScopeEnd registers fn to be run by End, e.g. to roll back a
+	// transaction or finish a span mapped into a request scope.
+	OnScopeEnd(fn func())
+	// End runs the functions registered with OnScopeEnd in reverse order of
+	// registration, once. It does not affect parents or children.
+	End()
+	// OnExpire registers fn to be called with the old value of t whenever a
+	// registration in the injector replaces the binding of t with a
+	// different value, or a Weak provider of t drops its value, e.g. to close
+	// a connection replaced during credential rotation. Provided values that
+	// were never constructed do not expire. fn is called after the
+	// registration completed and may use the injector.
+	OnExpire(t reflect.Type, fn func(old reflect.Value))
+	// Stats returns statistics about the bindings and lookups of the injector,
+	// not its parents. Lookup counts are only collected for injectors created
+	// with WithStats.
+	Stats() Stats
+	// OriginOf reports which injector of the parent chain, and which
+	// registration, supplies the binding resolving the type. It reports false
+	// if the type cannot be resolved.
+	OriginOf(reflect.Type) (BindingInfo, bool)
+	// ValueByName resolves the type with the given qualified name, such as
+	// "*github.com/acme/app/db.Client" or "github.com/acme/app/db.*Client",
+	// for callers that only know types by name. Besides the bound types of
+	// the injector and its parents, the types passed to RegisterTypeName are
+	// known by name.
+	ValueByName(name string) (reflect.Value, error)
+	// AuditLog returns the latest changes of the bindings of the injector, not
+	// its parents, oldest first. Changes are only recorded by injectors
+	// created WithAuditLog.
+	AuditLog() []AuditEntry
+	// Label returns the label the injector was created WithLabel, or "" if it
+	// has none.
+	Label() string
+	// Export returns the values bound in the injector, not its parents, e.g.
+	// to hand them to another system or to restore them with NewFrom.
+	Export() map[reflect.Type]reflect.Value
+	// MemoryReport estimates the memory retained by every value bound in the
+	// injector, not its parents, largest first. If deep is true, everything
+	// reachable from the values is sized as well.
+	MemoryReport(deep bool) []MemoryUsage
+	// Err returns the first misuse of a method of the injector that cannot
+	// return an error, if it was created WithErrorsOnly, or nil.
+	Err() error
+	// Manifest returns a stable JSON description of every binding of the
+	// injector and its parents, with its type, scope, registration method,
+	// provider lifetime and, for injectors created WithCallSites, origin.
+	Manifest() ([]byte, error)
+	// VerifyManifest compares the bindings of the injector with a manifest
+	// returned by Manifest, e.g. one committed with the deployment. It returns
+	// an error matching ErrWiringChanged that lists every added, removed or
+	// changed binding. Origins are not compared.
+	VerifyManifest(manifest []byte) error
 }
 
 // Applicator represents an interface for mapping dependencies to a struct.
 type Applicator interface {
 	// Apply maps dependencies in the Type map to each field in the struct that is
-	// tagged with "inject". Returns an error if the injection fails.
-	Apply(interface{}) error
+	// tagged with "inject", with the InvokeOptions applying as for Invoke. A
+	// tag of `inject:"type=*pkg.Impl"` selects the
+	// binding of the named concrete type when several implement the field's
+	// interface, and `inject:"group=name"` fills a slice field with the values
+	// of the named group. A map[string]interface{} has the value of every key
+	// set to the value of the type named by the key, as for ValueByName.
+	// Returns an error if the injection fails.
+	Apply(interface{}, ...InvokeOption) error
+	// ApplyFuncs fills the nil exported fields of function type of the struct
+	// `interface{}` points to, from bindings of the function type or from
+	// methods of bound values with the field's name and signature.
+	ApplyFuncs(interface{}) error
+	// Extract fills every exported field of the struct `interface{}` points
+	// to, tagged or not, and fails for fields that cannot be resolved.
+	Extract(interface{}) error
 }
 
 // Invoker represents an interface for calling functions via reflection.
@@ -34,8 +136,49 @@ type Invoker interface {
 	// Invoke attempts to call the `interface{}` provided as a function, providing
 	// dependencies for function arguments based on Type. Returns a slice of
 	// reflect.Value representing the returned values of the function. Returns an
-	// error if the injection fails.
-	Invoke(interface{}) ([]reflect.Value, error)
+	// error if the injection fails. InvokeOptions supply values for this call
+	// only, taking precedence over the bindings of the Injector.
+	Invoke(interface{}, ...InvokeOption) ([]reflect.Value, error)
+	// InvokeScoped calls the function `interface{}` like Invoke, in a throwaway
+	// child scope to which `setup` adds the bindings needed by this call only.
+	InvokeScoped(f interface{}, setup func(TypeMapper)) ([]reflect.Value, error)
+	// InvokeMemo calls the function `interface{}` like Invoke, but returns the
+	// results of the previous call as long as no binding of the injector or
+	// its parents has changed since. It is meant for expensive pure functions.
+	InvokeMemo(interface{}) ([]reflect.Value, error)
+	// InvokeContext calls the function `f` like Invoke, with ctx mapped as
+	// context.Context. Constructing lazily provided arguments fails once ctx
+	// is done.
+	InvokeContext(ctx context.Context, f interface{}, opts ...InvokeOption) ([]reflect.Value, error)
+	// InvokeMethod attempts to call the method named `method` of `receiver`,
+	// providing dependencies for its arguments based on Type. Returns a slice of
+	// reflect.Value representing the returned values of the method. Returns an
+	// error if the method does not exist or the injection fails.
+	InvokeMethod(receiver interface{}, method string) ([]reflect.Value, error)
+	// CanInvoke checks whether every argument of the function `interface{}`
+	// can be resolved, without resolving or constructing any of them. Returns
+	// a *MissingDependencyError for the first argument that cannot be
+	// resolved.
+	CanInvoke(interface{}) error
+	// Explain reports for every argument of the function `interface{}` which
+	// binding, in which injector of the parent chain, would satisfy it.
+	Explain(interface{}) []ArgResolution
+	// Curry returns a function taking only the arguments of the function
+	// `interface{}` that cannot be resolved, calling it with the resolvable
+	// ones bound now.
+	Curry(interface{}) (interface{}, error)
+	// InvokeNamed calls the function `interface{}` like Invoke and returns
+	// its results by name: the names given to a NamedFunc, or the exported
+	// fields of a struct result, with a trailing error result named "error".
+	InvokeNamed(interface{}, ...InvokeOption) (map[string]reflect.Value, error)
+}
+
+// MethodInjector can be implemented by a struct applied by an Injector created
+// with WithApplyMethods to name methods that should be called with all of their
+// arguments resolved from the Type map.
+type MethodInjector interface {
+	// InjectMethods returns the names of the methods to be called.
+	InjectMethods() []string
 }
 
 // FastInvoker represents an interface in order to avoid the calling function
@@ -58,7 +201,8 @@ func IsFastInvoker(handler interface{}) bool {
 // type.
 type TypeMapper interface {
 	// Map maps the `interface{}` values based on their immediate type from
-	// reflect.TypeOf.
+	// reflect.TypeOf. Attributes made by Meta are not mapped but attached to
+	// the bindings of the other values.
 	Map(...interface{}) TypeMapper
 	// MapTo maps the `interface{}` value based on the pointer of an Interface
 	// provided. This is really only useful for mapping a value as an interface, as
@@ -68,40 +212,229 @@ type TypeMapper interface {
 	// value. This makes it possible to directly map type arguments not possible to
 	// instantiate with reflect like unidirectional channels.
 	Set(reflect.Type, reflect.Value) TypeMapper
+	// MapToE is MapTo returning misuse such as ErrNotInterfacePointer,
+	// ErrImmutable or ErrProtectedBinding as an error instead of panicking.
+	MapToE(val interface{}, pointerToInterface interface{}) error
+	// SetE is Set returning misuse as an error instead of panicking.
+	SetE(reflect.Type, reflect.Value) error
 	// Value returns the reflect.Value that is mapped to the reflect.Type. It
 	// returns a zeroed reflect.Value if the Type has not been mapped.
 	Value(reflect.Type) reflect.Value
+	// Values resolves the types in order, as a framework resolving a known set
+	// of types per request would with Value, but reads the values mapped into
+	// the injector itself under a single lock. It returns an error matching
+	// ErrValueNotFound for the first type that cannot be resolved, or the
+	// error constructing it.
+	Values(ts ...reflect.Type) ([]reflect.Value, error)
+	// ValueLocal returns the reflect.Value that is mapped to the reflect.Type
+	// in the injector itself, without consulting its parents.
+	ValueLocal(reflect.Type) reflect.Value
 	// Load value into val. It returns an error if the value is not found or value can't set.
+	// If the pointer type of val is not bound, val is set to the value of its
+	// element type. Pointers to slices, arrays and maps that are not bound are
+	// filled with the assignable values of every group, or for maps keyed by
+	// reflect.Type or string, of every binding. Otherwise a bound value of a
+	// compatible type is converted: numbers into other number types, strings
+	// and byte slices into each other, and interfaces into interfaces their
+	// value implements. A conversion that would lose information fails with
+	// an error matching ErrValueCanNotSet.
 	Load(val interface{}) error
+	// Provide registers a constructor for the types of its results, which is
+	// called with arguments resolved from the Type map the first time one of
+	// them is requested. A last result of type error is returned as error of
+	// the resolution instead of being bound, and results of struct types
+	// embedding Out bind each of their exported fields instead. ProvideOptions
+	// such as WithLifetime(Prototype) change how often constructor is called.
+	// Returns an error if constructor is not a valid constructor.
+	Provide(constructor interface{}, opts ...ProvideOption) error
+	// Supply maps the `interface{}` values as-is based on their immediate type,
+	// like Map, even if they are functions. It is the explicit counterpart of
+	// Provide, and returns an error if a value is nil.
+	Supply(...interface{}) error
+	// MapPool binds the type to values leased from pool: the first resolution
+	// in a scope gets a value from the pool, which is resolved for the rest of
+	// the scope and put back into the pool when the scope ends.
+	MapPool(typ reflect.Type, pool Pool) TypeMapper
+	// MapGroup adds the `interface{}` values to the named group, which slice
+	// fields tagged with `inject:"group=name"` receive in registration order.
+	MapGroup(group string, values ...interface{}) TypeMapper
+	// MapProtected maps the `interface{}` values like Map, and protects their
+	// bindings from being replaced in the injector or shadowed by its
+	// children, which fail with ErrProtectedBinding.
+	MapProtected(values ...interface{}) TypeMapper
 }
 
 var _ Injector = (*injector)(nil)
 
+// smallArgs is the number of arguments callInvoke passes without allocating.
+const smallArgs = 4
+
+var argsPool = sync.Pool{
+	New: func() interface{} {
+		in := make([]reflect.Value, 0, 2*smallArgs)
+		return &in
+	},
+}
+
+func putArgs(p *[]reflect.Value) {
+	in := (*p)[:cap(*p)]
+	for i := range in {
+		in[i] = reflect.Value{}
+	}
+	argsPool.Put(p)
+}
+
 type injector struct {
-	values map[reflect.Type]reflect.Value
-	parent Injector
-	mu     sync.RWMutex
+	// gen is incremented by every change of the bindings or the parent. It is
+	// the first field to be 64-bit aligned for atomic access.
+	gen uint64
+
+	values map[reflect.Type]binding
+	parent unsafe.Pointer // *parentRef, accessed atomically
+	// self refers to the injector, for its children to point to as their
+	// parent without allocating.
+	self  parentRef
+	opts  *options
+	label string
+	mu    sync.RWMutex
+
+	// ifaces indexes interface types to the type of the binding implementing
+	// them, or to nil if no binding does.
+	ifaces map[reflect.Type]reflect.Type
+	// misses records the generation at which lookups of a type found nothing.
+	misses map[reflect.Type]uint64
+	// ctx bounds the construction of lazy providers, see InvokeContext.
+	ctx context.Context
+	// counters is only allocated if the injector collects Stats.
+	counters *counters
+	// template is the ScopeTemplate the injector was created by, whose types
+	// are bound to the slots of the extension.
+	template *ScopeTemplate
+	// ext is the extension of the injector, see extension.
+	ext unsafe.Pointer // *extension, accessed atomically
+
+	// hasProtected is set once the extension holds protected types, and
+	// hasOnExpire once it holds OnExpire callbacks, so that they are checked
+	// without locking.
+	hasProtected uint32 // accessed atomically
+	hasOnExpire  uint32 // accessed atomically
+	// frozen is set by Builder.Build before the injector is shared. The
+	// bindings of a frozen injector cannot change, so lookups take no locks.
+	frozen bool
+	// local stops lookups starting at the injector at its parent, see
+	// LocalOnly.
+	local bool
+}
+
+// extension holds the state of an injector that most scopes never need. It is
+// allocated on first use, so that creating a scope only allocates what
+// lookups use. Its fields are guarded by the lock of the injector, except
+// those set before the injector is shared.
+type extension struct {
+	checks map[string]HealthCheck
+	audits *auditLog
+	// conflicts holds the conflicts found under the lock, to be reported
+	// once it is released, see WithConflicts.
+	conflicts []Conflict
+	// misused is the first misuse recorded, see WithErrorsOnly.
+	misused error
+	// protected holds the types bound by MapProtected.
+	protected map[reflect.Type]bool
+
+	journal    []journalEntry
+	journaling bool
+
+	// groups holds the values of named groups in registration order.
+	groups map[string][]reflect.Value
+	// onEnd holds the functions run by End.
+	onEnd []func()
+	// onExpire holds the callbacks of OnExpire by type, and expired queues
+	// the values to pass them once the lock is released.
+	onExpire map[reflect.Type][]func(reflect.Value)
+	expired  []expiredValue
+	// leak reports the scope if it is not ended in time, see
+	// WithLeakDetection.
+	leak *time.Timer
+	// frozenIfaces is the interface index of a frozen injector, allocated
+	// before it is frozen.
+	frozenIfaces sync.Map
+	// memo holds the results of InvokeMemo by function code.
+	memo map[uintptr]memoEntry
+	// slots holds the values given to NewScope, set before the scope is
+	// returned.
+	slots []reflect.Value
+}
+
+// extension returns the extension of inj, or nil if it has none yet.
+func (inj *injector) extension() *extension {
+	return (*extension)(atomic.LoadPointer(&inj.ext))
+}
+
+// extend returns the extension of inj, allocating it first if needed. The
+// caller must hold the write lock, or not have shared inj yet.
+func (inj *injector) extend() *extension {
+	if ext := inj.extension(); ext != nil {
+		return ext
+	}
+	ext := &extension{}
+	atomic.StorePointer(&inj.ext, unsafe.Pointer(ext))
+	return ext
 }
 
 // InterfaceOf dereferences a pointer to an Interface type. It panics if value
 // is not a pointer to an interface.
 func InterfaceOf(value interface{}) reflect.Type {
+	t, err := InterfaceOfE(value)
+	if err != nil {
+		panic("called inject.InterfaceOf with a value that is not a pointer to an interface. (*MyInterface)(nil)")
+	}
+	return t
+}
+
+// InterfaceOfE dereferences a pointer to an Interface type. It returns
+// ErrNotInterfacePointer if value is not a pointer to an interface.
+func InterfaceOfE(value interface{}) (reflect.Type, error) {
 	t := reflect.TypeOf(value)
-	for t.Kind() == reflect.Ptr {
+	for t != nil && t.Kind() == reflect.Ptr {
 		t = t.Elem()
 	}
 
-	if t.Kind() != reflect.Interface {
-		panic("called inject.InterfaceOf with a value that is not a pointer to an interface. (*MyInterface)(nil)")
+	if t == nil || t.Kind() != reflect.Interface {
+		return nil, fmt.Errorf("%w: %T", ErrNotInterfacePointer, value)
 	}
-	return t
+	return t, nil
+}
+
+// New returns a new Injector configured with opts.
+func New(opts ...Option) Injector {
+	inj := &injector{
+		opts: newOptions(defaultOptions, opts),
+	}
+	inj.self.Injector = inj
+	inj.label = inj.opts.label
+	if inj.opts.stats {
+		inj.counters = &counters{}
+	}
+	if inj.opts.clock {
+		inj.set(clockType, reflect.ValueOf(SystemClock), "WithClock", nil)
+	}
+	return inj
 }
 
-// New returns a new Injector.
-func New() Injector {
-	return &injector{
-		values: make(map[reflect.Type]reflect.Value),
+func (inj *injector) Child(opts ...Option) Injector {
+	child := &injector{
+		opts:  newOptions(inj.opts, opts),
+		label: labelOf(opts),
 	}
+	child.self.Injector = child
+	child.storeParent(inj)
+	if child.opts.stats {
+		child.counters = &counters{}
+	}
+	if child.opts.leaks != nil {
+		child.watch(child.opts.leaks)
+	}
+	return child
 }
 
 // Invoke attempts to call the interface{} provided as a function,
@@ -109,27 +442,99 @@ func New() Injector {
 // Returns a slice of reflect.Value representing the returned values of the function.
 // Returns an error if the injection fails.
 // It panics if f is not a function
-func (inj *injector) Invoke(f interface{}) ([]reflect.Value, error) {
+func (inj *injector) Invoke(f interface{}, opts ...InvokeOption) ([]reflect.Value, error) {
+	if len(opts) > 0 {
+		scope := inj.callScope(opts)
+		defer scope.End()
+		return scope.Invoke(f)
+	}
+	if inj.opts.pprofLabels != nil {
+		if ctx := inj.labelContext(); ctx != nil {
+			return inj.invokeLabeled(ctx, f)
+		}
+	}
+	return inj.invoke(f)
+}
+
+// invokeLabels returns the pprof labels for invoking f.
+func (inj *injector) invokeLabels(f interface{}) []string {
+	return append([]string{"inject.func", targetName(f)}, inj.opts.pprofLabels...)
+}
+
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+// labelContext returns the context whose pprof labels an invocation adds its
+// labels to: the context given to InvokeContext, or else the context.Context
+// bound in the injector. It returns nil if there is none, as the labels of
+// the goroutine cannot be read and would be lost.
+func (inj *injector) labelContext() context.Context {
+	if inj.ctx != nil {
+		return inj.ctx
+	}
+	if v := inj.Value(contextType); v.IsValid() {
+		ctx, _ := v.Interface().(context.Context)
+		return ctx
+	}
+	return nil
+}
+
+func (inj *injector) invoke(f interface{}) ([]reflect.Value, error) {
+	if err := checkFunc(f); err != nil {
+		return nil, inj.misuseErr(err)
+	}
+	if inj.opts.limits != nil {
+		release, err := inj.acquireLimit(f)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+	if tr := inj.opts.trace; tr != nil {
+		tr.printf("invoke %s", targetName(f))
+	}
 	t := reflect.TypeOf(f)
 	switch v := f.(type) {
 	case FastInvoker:
 		return inj.fastInvoke(v, t, t.NumIn())
 	default:
-		return inj.callInvoke(f, t, t.NumIn())
+		return inj.callInvoke(reflect.ValueOf(f), t, t.NumIn())
 	}
 }
 
+// InvokeMethod attempts to call the method named method of receiver,
+// providing dependencies for its arguments based on Type.
+// Returns an error if receiver has no such exported method or the injection fails.
+func (inj *injector) InvokeMethod(receiver interface{}, method string) ([]reflect.Value, error) {
+	if lightMode {
+		return nil, ErrUnsupported
+	}
+	m := reflect.ValueOf(receiver).MethodByName(method)
+	if !m.IsValid() {
+		return nil, fmt.Errorf("%w: %T.%s", ErrMethodNotFound, receiver, method)
+	}
+	t := m.Type()
+	return inj.callInvoke(m, t, t.NumIn())
+}
+
 func (inj *injector) fastInvoke(f FastInvoker, t reflect.Type, numIn int) ([]reflect.Value, error) {
 	var in []interface{}
 	if numIn > 0 {
 		in = make([]interface{}, numIn) // Panic if t is not kind of Func
+		var consumer string
+		if inj.namesConsumers() {
+			consumer = targetName(f)
+		}
 		var argType reflect.Type
 		var val reflect.Value
+		var err error
 		for i := 0; i < numIn; i++ {
 			argType = t.In(i)
-			val = inj.Value(argType)
+			val, err = inj.resolveFor(argType, consumer)
+			if err != nil {
+				return nil, err
+			}
 			if !val.IsValid() {
-				return nil, fmt.Errorf("%w: %v", ErrValueNotFound, argType)
+				return nil, inj.missing(argType, targetName(f))
 			}
 
 			in[i] = val.Interface()
@@ -138,112 +543,436 @@ func (inj *injector) fastInvoke(f FastInvoker, t reflect.Type, numIn int) ([]ref
 	return f.Invoke(in)
 }
 
-func (inj *injector) callInvoke(f interface{}, t reflect.Type, numIn int) ([]reflect.Value, error) {
+func (inj *injector) callInvoke(f reflect.Value, t reflect.Type, numIn int) ([]reflect.Value, error) {
+	if numIn == 0 {
+		return f.Call(nil), nil
+	}
+
+	// Arguments of functions with few parameters live on the stack, larger
+	// argument slices are pooled. reflect.Value.Call still allocates the
+	// results and the conversion of arguments to interface parameters.
 	var in []reflect.Value
-	if numIn > 0 {
-		in = make([]reflect.Value, numIn)
-		var argType reflect.Type
-		var val reflect.Value
-		for i := 0; i < numIn; i++ {
-			argType = t.In(i)
-			val = inj.Value(argType)
-			if !val.IsValid() {
-				return nil, fmt.Errorf("%w: %v", ErrValueNotFound, argType)
-			}
+	var buf [smallArgs]reflect.Value
+	if numIn <= smallArgs {
+		in = buf[:numIn]
+	} else {
+		p := argsPool.Get().(*[]reflect.Value)
+		defer putArgs(p)
+		if cap(*p) < numIn {
+			*p = make([]reflect.Value, numIn)
+		}
+		in = (*p)[:numIn]
+	}
 
-			in[i] = val
+	var consumer string
+	if inj.namesConsumers() {
+		consumer = funcName(f)
+	}
+	var argType reflect.Type
+	var val reflect.Value
+	var err error
+	for i := 0; i < numIn; i++ {
+		argType = t.In(i)
+		val, err = inj.resolveFor(argType, consumer)
+		if err != nil {
+			return nil, err
 		}
+		if !val.IsValid() {
+			return nil, inj.missing(argType, funcName(f))
+		}
+
+		in[i] = val
 	}
-	return reflect.ValueOf(f).Call(in), nil
+	return f.Call(in), nil
 }
 
-func (inj *injector) Apply(val interface{}) error {
+func (inj *injector) Apply(val interface{}, opts ...InvokeOption) error {
+	if len(opts) > 0 {
+		scope := inj.callScope(opts)
+		defer scope.End()
+		return scope.Apply(val)
+	}
 	v := reflect.ValueOf(val)
 
-	for v.Kind() == reflect.Ptr {
+	for v.Kind() == reflect.Ptr && v.Elem().Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	receiver := v
+	if v.Kind() == reflect.Ptr {
 		v = v.Elem()
 	}
 
+	if v.Kind() == reflect.Map {
+		return inj.applyMap(v)
+	}
 	if v.Kind() != reflect.Struct {
 		return nil // Should not panic here ?
 	}
 
 	t := v.Type()
+	if tr := inj.opts.trace; tr != nil {
+		tr.printf("apply %v", t)
+	}
 
-	for i := 0; i < v.NumField(); i++ {
-		f := v.Field(i)
-		structField := t.Field(i)
-		_, ok := structField.Tag.Lookup("inject")
-		if f.CanSet() && ok {
-			ft := f.Type()
-			v := inj.Value(ft)
-			if !v.IsValid() {
-				return fmt.Errorf("%w: %v", ErrValueNotFound, ft)
+	if v.CanSet() {
+		var plan *structPlan
+		if f := inj.opts.fieldFilter; f != nil {
+			plan = f.plan(t)
+		} else {
+			plan = plans.get(t)
+		}
+		for _, pf := range plan.fields {
+			fv, err := inj.resolveField(pf.typ, pf.tag, pf.consumer, nil)
+			if err != nil {
+				return err
 			}
+			v.Field(pf.index).Set(fv)
+		}
+	}
+
+	if inj.opts.applyMethods {
+		return inj.applyMethods(receiver)
+	}
+	return nil
+}
 
-			f.Set(v)
+// applyMethods calls the setter methods of receiver with resolved arguments.
+func (inj *injector) applyMethods(receiver reflect.Value) error {
+	if lightMode {
+		return ErrUnsupported
+	}
+	t := receiver.Type()
+	for i := 0; i < t.NumMethod(); i++ {
+		m := t.Method(i)
+		if !strings.HasPrefix(m.Name, "Set") || m.Type.NumIn() != 2 {
+			continue
 		}
+		val := inj.Value(m.Type.In(1))
+		if !val.IsValid() {
+			continue
+		}
+		if err := methodError(receiver.Method(i).Call([]reflect.Value{val})); err != nil {
+			return err
+		}
+	}
 
+	mi, ok := receiver.Interface().(MethodInjector)
+	if !ok {
+		return nil
+	}
+	for _, name := range mi.InjectMethods() {
+		m := receiver.MethodByName(name)
+		if !m.IsValid() {
+			return fmt.Errorf("%w: %v.%s", ErrMethodNotFound, t, name)
+		}
+		mt := m.Type()
+		out, err := inj.callInvoke(m, mt, mt.NumIn())
+		if err != nil {
+			return err
+		}
+		if err = methodError(out); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+// methodError returns the last value of out if it is a non-nil error.
+func methodError(out []reflect.Value) error {
+	if len(out) == 0 {
+		return nil
+	}
+	err, _ := out[len(out)-1].Interface().(error)
+	return err
+}
+
 func (inj *injector) Map(values ...interface{}) TypeMapper {
+	if !inj.mutable() {
+		return inj
+	}
+	values, meta := splitMeta(values)
+	for _, val := range values {
+		if !inj.mustBindable(reflect.TypeOf(val)) {
+			return inj
+		}
+	}
+	site := inj.callSite(1)
 	inj.mu.Lock()
 	for _, val := range values {
-		inj.values[reflect.TypeOf(val)] = reflect.ValueOf(val)
+		inj.audit("Map", reflect.TypeOf(val), site)
+		inj.store(reflect.TypeOf(val), binding{value: reflect.ValueOf(val), method: "Map", site: site, meta: meta})
 	}
 	inj.mu.Unlock()
+	inj.notify()
 	return inj
 }
 
-func (inj *injector) MapTo(val, ifacePtr interface{}) TypeMapper {
+func (inj *injector) Supply(values ...interface{}) error {
+	if inj.frozen {
+		return ErrImmutable
+	}
+	values, meta := splitMeta(values)
+	for i, val := range values {
+		if val == nil {
+			return fmt.Errorf("%w: value %d supplied without a type", ErrNilValue, i)
+		}
+		if err := inj.bindable(reflect.TypeOf(val)); err != nil {
+			return err
+		}
+	}
+	site := inj.callSite(1)
 	inj.mu.Lock()
-	inj.values[InterfaceOf(ifacePtr)] = reflect.ValueOf(val)
+	for _, val := range values {
+		inj.audit("Supply", reflect.TypeOf(val), site)
+		inj.store(reflect.TypeOf(val), binding{value: reflect.ValueOf(val), method: "Supply", site: site, meta: meta})
+	}
 	inj.mu.Unlock()
-	return inj
+	inj.notify()
+	return nil
+}
+
+func (inj *injector) MapTo(val, ifacePtr interface{}) TypeMapper {
+	t, err := InterfaceOfE(ifacePtr)
+	if err != nil {
+		inj.misuse(err)
+		return inj
+	}
+	return inj.set(t, reflect.ValueOf(val), "MapTo", inj.callSite(1))
 }
 
 func (inj *injector) Set(typ reflect.Type, val reflect.Value) TypeMapper {
+	return inj.set(typ, val, "Set", inj.callSite(1))
+}
+
+// set binds typ to val on behalf of the registration method.
+func (inj *injector) set(typ reflect.Type, val reflect.Value, method string, site *callSite) TypeMapper {
+	if err := inj.setE(typ, val, method, site); err != nil {
+		inj.misuse(err)
+	}
+	return inj
+}
+
+// setE is set returning misuse as an error.
+func (inj *injector) setE(typ reflect.Type, val reflect.Value, method string, site *callSite) error {
+	if inj.frozen {
+		return ErrImmutable
+	}
+	if err := inj.bindable(typ); err != nil {
+		return err
+	}
 	inj.mu.Lock()
-	inj.values[typ] = val
+	inj.audit(method, typ, site)
+	inj.store(typ, binding{value: val, method: method, site: site})
 	inj.mu.Unlock()
-	return inj
+	inj.notify()
+	return nil
 }
 
 func (inj *injector) Value(t reflect.Type) reflect.Value {
-	inj.mu.RLock()
-	val := inj.values[t]
-	inj.mu.RUnlock()
+	val, _ := inj.resolve(t)
+	return val
+}
+
+// resolve returns the value bound to t in the injector or its parents. It
+// returns an invalid value if t is not bound, and an error if constructing
+// the value of a provided type fails.
+func (inj *injector) resolve(t reflect.Type) (reflect.Value, error) {
+	return inj.resolveFor(t, "")
+}
+
+// namesConsumers reports whether resolutions from inj use the consumer name.
+func (inj *injector) namesConsumers() bool {
+	return inj.opts.firstUse != nil || inj.opts.denied != nil || inj.opts.deprecated != nil ||
+		inj.opts.authorize != nil
+}
+
+// resolveFor is resolve on behalf of consumer, which is reported to the
+// WithFirstUse hook and in ErrDenied errors. Callers that have to compute the
+// name only do so if namesConsumers.
+func (inj *injector) resolveFor(t reflect.Type, consumer string) (reflect.Value, error) {
+	return inj.resolveWithin(t, consumer, nil)
+}
+
+// resolveWithin is resolveFor on behalf of the constructions in chain.
+func (inj *injector) resolveWithin(t reflect.Type, consumer string, chain *building) (reflect.Value, error) {
+	if t.Kind() == reflect.Struct && isInStruct(t) {
+		return inj.resolveIn(t, chain)
+	}
+	var buf [16]uint64
+	v, err := inj.resolveAt(t, consumer, inj, inj.opts.trace, 0, chain, inj.chainGenerations(buf[:0]))
+	if !v.IsValid() && err == nil && inj.opts.aliases != nil {
+		if alias, ok := inj.opts.aliases[t]; ok {
+			inj.opts.trace.trace(0, inj.label, t, "alias of %v", alias)
+			if v, err = inj.resolveWithin(alias, consumer, chain); v.IsValid() {
+				v = v.Convert(t)
+			}
+		}
+	}
+	if !v.IsValid() && err == nil && !inj.local {
+		if fb := interfaceFallback(t); fb != nil {
+			inj.opts.trace.trace(0, inj.label, t, "interface fallback")
+			v, err = inj.useFallback(t, fb, consumer)
+		}
+	}
+	if v.IsValid() && inj.opts.transforms != nil {
+		if fn := inj.opts.transforms[t]; fn != nil {
+			v = fn(v)
+		}
+	}
+	if r := inj.opts.recorder; r != nil {
+		r.record(inj, t, err)
+	}
+	if hook := inj.opts.resolveHook; hook != nil {
+		hook(t, v, err)
+	}
+	return v, err
+}
+
+// resolveAt resolves t for consumer at the given level of the parent chain of
+// origin, tracing the lookup to tr if it is not nil. chain holds the
+// constructions t is resolved for, and gens the generations of inj and its
+// parents, see chainGenerations.
+func (inj *injector) resolveAt(t reflect.Type, consumer string, origin *injector, tr *tracer, level int, chain *building, gens []uint64) (reflect.Value, error) {
+	gen, own, cacheable := inj.levelGeneration(gens)
+	var missGen uint64
+	var missed bool
+	b := inj.slotBinding(t)
+	if b.bound() {
+		// Values of a ScopeTemplate scope are found without map lookups.
+	} else if inj.frozen {
+		b = inj.values[t]
+		cacheable = false
+	} else {
+		inj.mu.RLock()
+		b = inj.values[t]
+		missGen, missed = inj.misses[t]
+		inj.mu.RUnlock()
+	}
 
-	if val.IsValid() {
-		return val
+	inj.counters.add(countLookups)
+	if !b.bound() && cacheable && missed && missGen == gen {
+		inj.counters.add(countMissCacheHits)
+		tr.trace(level, inj.label, t, "miss (cached)")
+		return reflect.Value{}, nil
 	}
 
-	// No concrete types found, try to find implementors if t is an interface.
-	if t.Kind() == reflect.Interface {
-		for k, v := range inj.values {
-			if k.Implements(t) {
-				val = v
-				break
+	bt := t
+	if b.bound() {
+		tr.trace(level, inj.label, t, "exact hit")
+	} else if t.Kind() == reflect.Interface {
+		// No concrete types found, try to find implementors if t is an interface.
+		if impl := inj.implementor(t); impl != nil {
+			if inj.frozen {
+				b = inj.values[impl]
+			} else {
+				inj.mu.RLock()
+				b = inj.values[impl]
+				inj.mu.RUnlock()
 			}
+			bt = impl
+			tr.trace(level, inj.label, t, "interface scan hit %v", impl)
 		}
 	}
+	if b.bound() {
+		if origin.opts.denied != nil {
+			if err := origin.denied(t, bt, consumer); err != nil {
+				return reflect.Value{}, err
+			}
+		}
+		if origin.opts.authorize != nil {
+			if err := origin.authorized(t, bt, consumer, inj, level, b); err != nil {
+				return reflect.Value{}, err
+			}
+		}
+		if origin.opts.deprecated != nil {
+			origin.warnDeprecated(t, bt, consumer)
+		}
+		inj.counters.add(countHits)
+		if b.markResolved() && inj.opts.firstUse != nil {
+			inj.opts.firstUse(FirstUse{Type: t, Bound: bt, Method: b.method, Consumer: consumer, Level: level, Time: time.Now()})
+		}
+		if b.leasable() {
+			return origin.acquire(bt, b.lease)
+		}
+		if b.provider != nil && origin.ctx != nil {
+			return b.provider.getContext(origin.ctx, t, b.out, chain)
+		}
+		return b.get(t, chain)
+	}
 
 	// Still no type found, try to look it up on the parent
-	if !val.IsValid() && inj.parent != nil {
-		val = inj.parent.Value(t)
+	if origin.local && level > 0 {
+		tr.trace(level, inj.label, t, "miss (local)")
+		return reflect.Value{}, nil
+	}
+	if p := inj.loadParent(); p != nil {
+		tr.trace(level, inj.label, t, "parent hop")
+		if len(gens) > 0 && atomic.LoadUint64(&inj.gen) != own {
+			// The parent was changed since gens were taken.
+			gens = nil
+		}
+		var val reflect.Value
+		var err error
+		if parent, ok := p.(*injector); ok {
+			if tr == nil {
+				tr = parent.opts.trace
+			}
+			val, err = parent.resolveAt(t, consumer, origin, tr, level+1, chain, tail(gens))
+		} else {
+			val = p.Value(t)
+			if val.IsValid() {
+				tr.trace(level+1, "", t, "hit in %T", p)
+				if origin.opts.denied != nil {
+					if err = origin.denied(t, t, consumer); err != nil {
+						return reflect.Value{}, err
+					}
+				}
+				if origin.opts.authorize != nil {
+					if err = origin.authorized(t, t, consumer, p, level+1, binding{}); err != nil {
+						return reflect.Value{}, err
+					}
+				}
+			}
+		}
+		if val.IsValid() || err != nil {
+			return val, err
+		}
+	} else {
+		tr.trace(level, inj.label, t, "miss")
 	}
 
-	return val
+	if cacheable {
+		inj.cacheMiss(t, gen)
+	}
+	return reflect.Value{}, nil
 }
 
 // Load value into val. It returns an error if the value is not found or value can't set.
 func (inj *injector) Load(val interface{}) error {
 	valType := reflect.TypeOf(val)
-	value := inj.Value(valType)
+	value, err := inj.resolveFor(valType, "Load")
+	if err != nil {
+		return err
+	}
+	if value.IsValid() {
+		value = value.Elem()
+	} else if valType != nil && valType.Kind() == reflect.Ptr {
+		elem := valType.Elem()
+		if value, err = inj.resolveFor(elem, "Load"); err == nil && !value.IsValid() {
+			switch elem.Kind() {
+			case reflect.Slice, reflect.Array, reflect.Map:
+				value, err = inj.loadCollection(elem)
+			}
+		}
+		if err == nil && !value.IsValid() {
+			value, err = inj.loadConverted(elem)
+		}
+		if err != nil {
+			return err
+		}
+	}
 	if !value.IsValid() {
-		return fmt.Errorf("%w: %v", ErrValueNotFound, valType)
+		return inj.missing(valType, "Load")
 	}
 	v := reflect.ValueOf(val)
 	if v.Kind() != reflect.Ptr {
@@ -253,18 +982,109 @@ func (inj *injector) Load(val interface{}) error {
 	if !v.CanSet() {
 		return fmt.Errorf("%w: %v", ErrValueCanNotSet, valType)
 	}
-	v.Set(value.Elem())
+	v.Set(value)
 	return nil
 }
 
-func (inj *injector) Reset() {
+// ResetOption changes the behavior of Injector.Reset.
+type ResetOption int
+
+const (
+	// KeepParent makes Reset keep the parent of the injector, e.g. to recycle
+	// request scopes without calling SetParent again.
+	KeepParent ResetOption = iota + 1
+)
+
+func (inj *injector) Reset(opts ...ResetOption) {
+	if !inj.mutable() {
+		return
+	}
+	keepParent := false
+	for _, opt := range opts {
+		if opt == KeepParent {
+			keepParent = true
+		}
+	}
+
+	site := inj.callSite(1)
+	inj.mu.Lock()
+	inj.audit("Reset", nil, site)
+	old, _ := inj.generation()
+	// The compiler turns this loop into a map clear that keeps the buckets.
 	for k := range inj.values {
 		delete(inj.values, k)
 	}
-	inj.parent = nil
+	inj.ifaces = nil
+	inj.misses = nil
+	atomic.StoreUint32(&inj.hasProtected, 0)
+	atomic.StoreUint32(&inj.hasOnExpire, 0)
+	if ext := inj.extension(); ext != nil {
+		ext.protected = nil
+		ext.checks = nil
+		ext.groups = nil
+		ext.onEnd = nil
+		ext.onExpire = nil
+		ext.expired = nil
+		ext.memo = nil
+		ext.journal = nil
+		ext.journaling = false
+	}
+	// Dropping the parent removes its generations, skip past them as
+	// SetParent does, before the parent is dropped.
+	atomic.AddUint64(&inj.gen, old+1)
+	if !keepParent {
+		inj.storeParent(nil)
+	}
+	inj.mu.Unlock()
 }
 
 func (inj *injector) SetParent(parent Injector) Injector {
-	inj.parent = parent
+	if !inj.mutable() {
+		return inj
+	}
+	// Skip the generation past every value the old chain could have had, so
+	// lookups cached by children of inj are not mistaken as current.
+	inj.mu.Lock()
+	// The generation changes before the parent, so that lookups seeing the
+	// new parent also see that generations taken before are stale.
+	old, _ := inj.generation()
+	atomic.AddUint64(&inj.gen, old+1)
+	inj.storeParent(parent)
+	inj.mu.Unlock()
 	return inj
 }
+
+// parentRef wraps the parent of an injector, so that it can be swapped
+// atomically. Parents created by this package are referred to by their self
+// field, others are wrapped once by storeParent.
+type parentRef struct {
+	Injector
+}
+
+// refOf returns the parentRef of parent, or nil if parent is nil.
+func refOf(parent Injector) *parentRef {
+	switch p := parent.(type) {
+	case nil:
+		return nil
+	case *injector:
+		if p == nil {
+			return nil
+		}
+		return &p.self
+	}
+	return &parentRef{parent}
+}
+
+// loadParent returns the parent of inj, or nil.
+func (inj *injector) loadParent() Injector {
+	if ref := (*parentRef)(atomic.LoadPointer(&inj.parent)); ref != nil {
+		return ref.Injector
+	}
+	return nil
+}
+
+// storeParent sets the parent of inj. It is safe to call concurrently with
+// lookups, which see either the old or the new parent.
+func (inj *injector) storeParent(parent Injector) {
+	atomic.StorePointer(&inj.parent, unsafe.Pointer(refOf(parent)))
+}
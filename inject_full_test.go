@@ -0,0 +1,132 @@
+//go:build !inject_light
+
+package inject
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime/pprof"
+	"strings"
+	"testing"
+)
+
+func TestInjector_ApplyMethods(t *testing.T) {
+	inj := New(WithApplyMethods())
+	inj.Map("a dep").MapTo("another dep", (*specialString)(nil))
+
+	s := setterStruct{}
+	expect(t, inj.Apply(&s), nil)
+	expect(t, s.dep1, "a dep")
+	expect(t, s.dep2, specialString("another dep"))
+	expect(t, s.skipped, 0)
+
+	inj.Map(&greeter{"Jeremy"})
+	expect(t, inj.Apply(&s).Error(), "failed for Jeremy")
+
+	inj2 := New()
+	inj2.Map("a dep")
+	s2 := setterStruct{}
+	expect(t, inj2.Apply(&s2), nil)
+	expect(t, s2.dep1, "")
+}
+
+func TestInjector_Child(t *testing.T) {
+	inj := New(WithApplyMethods())
+	inj.Map("a dep")
+
+	child := inj.Child()
+	expect(t, child.Value(reflect.TypeOf("")).Interface(), "a dep")
+
+	child.Map("child dep")
+	expect(t, child.Value(reflect.TypeOf("")).Interface(), "child dep")
+	expect(t, inj.Value(reflect.TypeOf("")).Interface(), "a dep")
+
+	s := setterStruct{}
+	expect(t, child.Apply(&s), nil)
+	expect(t, s.dep1, "child dep")
+}
+
+func TestInjector_InterfaceIndex(t *testing.T) {
+	inj := New()
+	stringer := InterfaceOf((*fmt.Stringer)(nil))
+	expect(t, inj.Value(stringer).IsValid(), false)
+
+	cp := inj.Checkpoint()
+	g := &greeter{"Jeremy"}
+	inj.Map(g)
+	expect(t, inj.Value(stringer).Interface(), g)
+
+	inj.ResetTo(cp)
+	expect(t, inj.Value(stringer).IsValid(), false)
+
+	inj.Map(g)
+	expect(t, inj.Value(stringer).Interface(), g)
+	inj.Reset()
+	expect(t, inj.Value(stringer).IsValid(), false)
+}
+
+func TestInjector_InvokeMethod(t *testing.T) {
+	inj := New()
+	inj.Map("Hi").MapTo("!", (*specialString)(nil))
+
+	result, err := inj.InvokeMethod(&greeter{"Jeremy"}, "Greet")
+	expect(t, err, nil)
+	expect(t, result[0].String(), "Hi Jeremy!")
+
+	_, err = inj.InvokeMethod(&greeter{}, "Missing")
+	expect(t, errors.Is(err, ErrMethodNotFound), true)
+
+	_, err = New().InvokeMethod(&greeter{}, "Greet")
+	expect(t, errors.Is(err, ErrValueNotFound), true)
+}
+
+func TestInjector_Map(t *testing.T) {
+	inj := New()
+
+	g := &greeter{"Jeremy"}
+	inj.Map(g)
+
+	expect(t, inj.Value(InterfaceOf((*fmt.Stringer)(nil))).IsValid(), true)
+}
+
+func TestInjector_PprofLabels(t *testing.T) {
+	inj := New(WithPprofLabels())
+	inj.Map("a dep")
+	child := inj.Child(WithPprofLabels("route", "/users")).(*injector)
+
+	labels := child.invokeLabels(TestInjector_PprofLabels)
+	expect(t, len(labels), 4)
+	expect(t, labels[0], "inject.func")
+	expect(t, labels[1], "github.com/juanjiTech/inject/v2.TestInjector_PprofLabels")
+	expect(t, labels[3], "/users")
+	expect(t, len(inj.(*injector).opts.pprofLabels), 0)
+
+	called := false
+	_, err := child.Invoke(func(dep string) { called = true })
+	expect(t, err, nil)
+	expect(t, called, true)
+
+	goroutineLabels := func() string {
+		var buf bytes.Buffer
+		_ = pprof.Lookup("goroutine").WriteTo(&buf, 1)
+		return buf.String()
+	}
+	pprof.Do(context.Background(), pprof.Labels("caller", "test"), func(ctx context.Context) {
+		var during string
+		_, err := child.InvokeContext(ctx, func(string) { during = goroutineLabels() })
+		expect(t, err, nil)
+		expect(t, strings.Contains(during, `"caller":"test"`), true)
+		expect(t, strings.Contains(during, `"route":"/users"`), true)
+
+		_, err = child.Invoke(func(string) { during = goroutineLabels() })
+		expect(t, err, nil)
+		expect(t, strings.Contains(during, `"route":"/users"`), false)
+		expect(t, strings.Contains(goroutineLabels(), `"caller":"test"`), true)
+	})
+
+	defer func() { expect(t, recover() != nil, true) }()
+	WithPprofLabels("route")
+}
@@ -1,6 +1,7 @@
 package inject
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"sync"
@@ -108,6 +109,10 @@ func TestInjector_Invoke(t *testing.T) {
 	})
 }
 
+func (g *greeter) Greet(prefix string, d2 specialString) string {
+	return prefix + " " + g.Name + d2.(string)
+}
+
 func TestInjector_Apply(t *testing.T) {
 	inj := New()
 	inj.Map("a dep").MapTo("another dep", (*specialString)(nil))
@@ -119,6 +124,18 @@ func TestInjector_Apply(t *testing.T) {
 	expect(t, "another dep", s.Dep2)
 }
 
+type setterStruct struct {
+	dep1    string
+	dep2    specialString
+	skipped int
+}
+
+func (s *setterStruct) SetDep1(d string)            { s.dep1 = d }
+func (s *setterStruct) SetSkipped(d int)            { s.skipped = d }
+func (s *setterStruct) Configure(d2 specialString)  { s.dep2 = d2 }
+func (s *setterStruct) InjectMethods() []string     { return []string{"Configure"} }
+func (s *setterStruct) SetFailing(g *greeter) error { return fmt.Errorf("failed for %s", g.Name) }
+
 func TestInjector_Load(t *testing.T) {
 	inj := New()
 
@@ -147,13 +164,102 @@ func TestInjector_InterfaceOf(t *testing.T) {
 	InterfaceOf((*testing.T)(nil))
 }
 
-func TestInjector_Map(t *testing.T) {
-	inj := New()
+func TestInjector_InterfaceOfE(t *testing.T) {
+	iType, err := InterfaceOfE((*specialString)(nil))
+	expect(t, err, nil)
+	expect(t, reflect.Interface, iType.Kind())
 
-	g := &greeter{"Jeremy"}
-	inj.Map(g)
+	_, err = InterfaceOfE((*testing.T)(nil))
+	expect(t, errors.Is(err, ErrNotInterfacePointer), true)
+	_, err = InterfaceOfE(nil)
+	expect(t, errors.Is(err, ErrNotInterfacePointer), true)
+}
+
+func TestInjector_MissCache(t *testing.T) {
+	parent := New()
+	inj := parent.Child()
+	typ := reflect.TypeOf(0)
+
+	expect(t, inj.Value(typ).IsValid(), false)
+	expect(t, inj.Value(typ).IsValid(), false)
+	parent.Map(1)
+	expect(t, inj.Value(typ).Interface(), 1)
+
+	inj.SetParent(New())
+	expect(t, inj.Value(typ).IsValid(), false)
+	inj.SetParent(parent)
+	expect(t, inj.Value(typ).Interface(), 1)
+}
 
-	expect(t, inj.Value(InterfaceOf((*fmt.Stringer)(nil))).IsValid(), true)
+func TestInjector_MissCacheReset(t *testing.T) {
+	parent := New()
+	for i := 0; i < 10; i++ {
+		parent.Map("parent")
+	}
+	inj := parent.Child()
+	child := inj.Child()
+	typ := reflect.TypeOf(0)
+
+	expect(t, child.Value(typ).IsValid(), false)
+	missGen, _ := child.(*injector).generation()
+
+	inj.Reset()
+	for gen, _ := child.(*injector).generation(); gen+1 < missGen; gen, _ = child.(*injector).generation() {
+		inj.Map("filler")
+	}
+	inj.Map(1)
+	expect(t, child.Value(typ).Interface(), 1)
+}
+
+func BenchmarkInjector_ValueMiss(b *testing.B) {
+	root := New()
+	for i := 0; i < 100; i++ {
+		root.Set(reflect.ArrayOf(i, reflect.TypeOf(0)), reflect.ValueOf(i))
+	}
+	inj := root.Child().Child()
+	stringer := InterfaceOf((*fmt.Stringer)(nil))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = inj.Value(stringer)
+	}
+}
+
+// BenchmarkInjector_ValueDeep resolves a value bound at the root of chains of
+// growing depth, whose cost should grow linearly.
+func BenchmarkInjector_ValueDeep(b *testing.B) {
+	for _, depth := range []int{4, 16, 64} {
+		b.Run(fmt.Sprint(depth), func(b *testing.B) {
+			inj := New()
+			inj.Map("root value")
+			for i := 0; i < depth; i++ {
+				inj = inj.Child()
+			}
+			typ := reflect.TypeOf("")
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_ = inj.Value(typ)
+			}
+		})
+	}
+}
+
+func BenchmarkInjector_ValueInterface(b *testing.B) {
+	inj := New()
+	for i := 0; i < 100; i++ {
+		inj.Set(reflect.ArrayOf(i, reflect.TypeOf(0)), reflect.ValueOf(i))
+	}
+	inj.Map(&greeter{"Jeremy"})
+	stringer := InterfaceOf((*fmt.Stringer)(nil))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = inj.Value(stringer)
+	}
 }
 
 func BenchmarkInjector_Map(b *testing.B) {
@@ -208,6 +314,12 @@ func TestInjector_Reset(t *testing.T) {
 
 	inj.Reset()
 	expect(t, inj.Value(reflect.TypeOf("string")).IsValid(), false)
+
+	inj.SetParent(injFather)
+	inj.Map(1)
+	inj.Reset(KeepParent)
+	expect(t, inj.Value(reflect.TypeOf(1)).IsValid(), false)
+	expect(t, inj.Value(reflect.TypeOf("string")).IsValid(), true)
 }
 
 func BenchmarkInjector_Reset(b *testing.B) {
@@ -219,6 +331,17 @@ func BenchmarkInjector_Reset(b *testing.B) {
 	}
 }
 
+func BenchmarkInjector_ResetKeepParent(b *testing.B) {
+	b.ReportAllocs()
+	inj := New()
+	inj.SetParent(New())
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		inj.Map("Jeremy", 1)
+		inj.Reset(KeepParent)
+	}
+}
+
 func TestInjector_SetParent(t *testing.T) {
 	inj := New()
 	inj.MapTo("another dep", (*specialString)(nil))
@@ -229,6 +352,43 @@ func TestInjector_SetParent(t *testing.T) {
 	expect(t, inj2.Value(InterfaceOf((*specialString)(nil))).IsValid(), true)
 }
 
+func TestInjector_ChildAllocs(t *testing.T) {
+	inj := New()
+	inj.Map("some dependency")
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = inj.Child()
+	})
+	expect(t, allocs, float64(1))
+	allocs = testing.AllocsPerRun(100, func() {
+		_ = New().SetParent(inj)
+	})
+	expect(t, allocs, float64(1))
+}
+
+func BenchmarkInjector_Child(b *testing.B) {
+	b.ReportAllocs()
+	inj := New()
+	inj.Map("some dependency")
+	var child Injector
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		child = inj.Child()
+	}
+	_ = child
+}
+
+func BenchmarkInjector_NewSetParent(b *testing.B) {
+	b.ReportAllocs()
+	inj := New()
+	inj.Map("some dependency")
+	var child Injector
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		child = New().SetParent(inj)
+	}
+	_ = child
+}
+
 func TestIsFastInvoker(t *testing.T) {
 	expect(t, IsFastInvoker(myFastInvoker(nil)), true)
 }
@@ -246,6 +406,32 @@ func BenchmarkInjector_Invoke(b *testing.B) {
 	}
 }
 
+func TestInjector_InvokeManyArgs(t *testing.T) {
+	inj := New()
+	inj.Map("a", 1, int8(2), int16(3), int32(4), int64(5))
+
+	for i := 0; i < 3; i++ {
+		result, err := inj.Invoke(func(s string, a int, b int8, c int16, d int32, e int64) int64 {
+			return int64(len(s)+a+int(b)+int(c)+int(d)) + e
+		})
+		expect(t, err, nil)
+		expect(t, result[0].Int(), int64(16))
+	}
+}
+
+func BenchmarkInjector_InvokeManyArgs(b *testing.B) {
+	inj := New()
+	inj.Map("a", 1, int8(2), int16(3), int32(4), int64(5))
+
+	fn := func(s string, a int, b int8, c int16, d int32, e int64) {}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = inj.Invoke(fn)
+	}
+}
+
 type testFastInvoker func(d1 string, d2 specialString) string
 
 func (f testFastInvoker) Invoke(args []interface{}) ([]reflect.Value, error) {
@@ -311,4 +497,48 @@ func TestConcurrent(t *testing.T) {
 		trigger.Done()
 		wg.Wait()
 	})
+	t.Run("SetParent", func(t *testing.T) {
+		child := inj.Child()
+		other := New()
+		other.Map(1)
+		var trigger, wg sync.WaitGroup
+		trigger.Add(1)
+		for i := 0; i < 1000; i++ {
+			wg.Add(1)
+			go func(i int) {
+				trigger.Wait()
+				if i%2 == 0 {
+					child.SetParent(other)
+				} else {
+					_ = child.Value(reflect.TypeOf(""))
+				}
+				wg.Done()
+			}(i)
+		}
+		trigger.Done()
+		wg.Wait()
+		expect(t, child.Value(reflect.TypeOf(1)).Interface(), 1)
+	})
+	t.Run("Reset", func(t *testing.T) {
+		child := inj.Child()
+		var trigger, wg sync.WaitGroup
+		trigger.Add(1)
+		for i := 0; i < 1000; i++ {
+			wg.Add(1)
+			go func(i int) {
+				trigger.Wait()
+				switch i % 3 {
+				case 0:
+					child.Reset(KeepParent)
+				case 1:
+					child.Map(i)
+				default:
+					_, _ = child.Invoke(func(string) {})
+				}
+				wg.Done()
+			}(i)
+		}
+		trigger.Done()
+		wg.Wait()
+	})
 }
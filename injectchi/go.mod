@@ -0,0 +1,10 @@
+module github.com/juanjiTech/inject/v2/injectchi
+
+go 1.18
+
+require (
+	github.com/go-chi/chi/v5 v5.0.12
+	github.com/juanjiTech/inject/v2 v2.0.0
+)
+
+replace github.com/juanjiTech/inject/v2 => ../
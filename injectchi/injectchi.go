@@ -0,0 +1,53 @@
+// Package injectchi adapts functions with injected arguments to handlers of
+// the github.com/go-chi/chi router.
+//
+// It is a separate module, so that the main module does not depend on chi.
+package injectchi
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/juanjiTech/inject/v2"
+	"github.com/juanjiTech/inject/v2/injecthttp"
+)
+
+// Middleware returns a chi middleware that makes inj the parent of the request
+// scopes created by handlers returned from Handler. It is meant to be
+// installed with chi.Router.Use.
+func Middleware(inj inject.Injector) func(http.Handler) http.Handler {
+	return injecthttp.Middleware(inj)
+}
+
+// Handler returns an http.HandlerFunc that calls fn for every request, like
+// injecthttp.Handler. In addition to the request types mapped by
+// injecthttp.NewScope, the request scope has the chi.RouteParams of the
+// matched route and the *chi.Context mapped, and the route parameters are the
+// injecthttp.PathValues of the request. It panics if fn is not a valid handler
+// function.
+func Handler(fn interface{}) http.HandlerFunc {
+	h := injecthttp.Handler(fn)
+	return func(w http.ResponseWriter, r *http.Request) {
+		rctx := chi.RouteContext(r.Context())
+		if rctx == nil {
+			rctx = chi.NewRouteContext()
+		}
+		params := rctx.URLParams
+		values := make(injecthttp.PathValues, len(params.Keys))
+		for i, key := range params.Keys {
+			values[key] = params.Values[i]
+		}
+
+		var scope inject.Injector
+		if parent := injecthttp.FromContext(r.Context()); parent != nil {
+			scope = parent.Child()
+		} else {
+			scope = inject.New()
+		}
+		defer scope.End()
+		scope.Map(params, rctx)
+
+		r = injecthttp.WithPathValues(r, values)
+		h.ServeHTTP(w, r.WithContext(injecthttp.NewContext(r.Context(), scope)))
+	}
+}
@@ -0,0 +1,65 @@
+package injectchi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/juanjiTech/inject/v2"
+	"github.com/juanjiTech/inject/v2/injecthttp"
+)
+
+func expect(t *testing.T, a interface{}, b interface{}) {
+	t.Helper()
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("Expected %v (type %v) - Got %v (type %v)", b, reflect.TypeOf(b), a, reflect.TypeOf(a))
+	}
+}
+
+type userStore map[string]string
+
+func TestHandler(t *testing.T) {
+	inj := inject.New()
+	inj.Map(userStore{"42": "Jeremy"})
+
+	r := chi.NewRouter()
+	r.Use(Middleware(inj))
+	r.Get("/users/{id}", Handler(func(users userStore, params chi.RouteParams, values injecthttp.PathValues, rctx *chi.Context) (int, string) {
+		expect(t, params.Keys, []string{"id"})
+		expect(t, rctx.URLParam("id"), "42")
+		return http.StatusOK, users[values["id"]]
+	}))
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/42", nil))
+	expect(t, rec.Code, http.StatusOK)
+	expect(t, rec.Body.String(), "Jeremy")
+}
+
+func TestHandler_WithoutMiddleware(t *testing.T) {
+	h := Handler(func(params chi.RouteParams, values injecthttp.PathValues) string {
+		expect(t, len(params.Keys), 0)
+		expect(t, len(values), 0)
+		return "ok"
+	})
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	expect(t, rec.Body.String(), "ok")
+}
+
+func TestHandler_ScopeEnd(t *testing.T) {
+	ended := 0
+	r := chi.NewRouter()
+	r.Use(Middleware(inject.New()))
+	r.Get("/", Handler(func(scope inject.Injector) int {
+		scope.OnScopeEnd(func() { ended++ })
+		return http.StatusNoContent
+	}))
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	expect(t, rec.Code, http.StatusNoContent)
+	expect(t, ended, 1)
+}
@@ -0,0 +1,107 @@
+// Package injectcron runs scheduled jobs whose arguments are injected.
+//
+// The package does not implement cron expressions itself, it adapts any
+// scheduler that can register a plain func() for a spec, e.g. for
+// github.com/robfig/cron/v3:
+//
+//	c := cron.New()
+//	s := injectcron.New(inj, func(spec string, cmd func()) error {
+//		_, err := c.AddFunc(spec, cmd)
+//		return err
+//	})
+//	_ = s.Add("@every 1m", func(now time.Time, repo *OrderRepo) error { ... })
+package injectcron
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/juanjiTech/inject/v2"
+)
+
+// Registrar registers cmd to be called on the schedule described by spec.
+type Registrar func(spec string, cmd func()) error
+
+// Option configures a Scheduler created by New.
+type Option func(*Scheduler)
+
+// WithContext sets the parent of the context.Context mapped for every run. It
+// defaults to context.Background().
+func WithContext(ctx context.Context) Option {
+	return func(s *Scheduler) {
+		s.ctx = ctx
+	}
+}
+
+// WithErrorHandler sets the function called with the spec and error of failed
+// runs: the injection error if the job could not be invoked, or the non-nil
+// error returned as the job's last result. Errors are dropped by default.
+func WithErrorHandler(fn func(spec string, err error)) Option {
+	return func(s *Scheduler) {
+		s.onError = fn
+	}
+}
+
+// Scheduler registers jobs with a Registrar and invokes them through an
+// Injector when they fire.
+type Scheduler struct {
+	inj      inject.Injector
+	register Registrar
+	onError  func(spec string, err error)
+	ctx      context.Context
+	cancel   context.CancelFunc
+}
+
+// New returns a Scheduler registering jobs with register and resolving their
+// arguments from inj.
+func New(inj inject.Injector, register Registrar, opts ...Option) *Scheduler {
+	s := &Scheduler{
+		inj:      inj,
+		register: register,
+		ctx:      context.Background(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.ctx, s.cancel = context.WithCancel(s.ctx)
+	return s
+}
+
+// Add registers job to run on the schedule described by spec. Every run gets a
+// fresh child scope of the Injector with the fire time mapped as time.Time and
+// the Scheduler's context mapped as context.Context, ended once the run
+// returns. It returns an error if job is not a function or the Registrar
+// rejects spec.
+func (s *Scheduler) Add(spec string, job interface{}) error {
+	if t := reflect.TypeOf(job); t == nil || t.Kind() != reflect.Func {
+		return fmt.Errorf("injectcron: job for %q is %T, not a function", spec, job)
+	}
+	return s.register(spec, func() {
+		if err := s.Run(job); err != nil && s.onError != nil {
+			s.onError(spec, err)
+		}
+	})
+}
+
+// Run invokes job once in a fresh child scope as if it had fired now.
+func (s *Scheduler) Run(job interface{}) error {
+	scope := s.inj.Child()
+	defer scope.End()
+	scope.Map(time.Now()).MapTo(s.ctx, (*context.Context)(nil))
+
+	out, err := scope.Invoke(job)
+	if err != nil {
+		return err
+	}
+	if len(out) > 0 {
+		err, _ = out[len(out)-1].Interface().(error)
+	}
+	return err
+}
+
+// Stop cancels the context mapped for running and future runs.
+func (s *Scheduler) Stop() {
+	s.cancel()
+}
@@ -0,0 +1,58 @@
+package injectcron
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/juanjiTech/inject/v2"
+)
+
+/* Test Helpers */
+func expect(t testing.TB, actual interface{}, expect interface{}) {
+	t.Helper()
+	if actual != expect {
+		t.Errorf("Expected %v (type %v) - Got %v (type %v)", expect, reflect.TypeOf(expect), actual, reflect.TypeOf(actual))
+	}
+}
+
+func TestScheduler(t *testing.T) {
+	inj := inject.New()
+	inj.Map("a dep")
+
+	jobs := map[string]func(){}
+	var failed []string
+	s := New(inj, func(spec string, cmd func()) error {
+		if spec == "" {
+			return errors.New("empty spec")
+		}
+		jobs[spec] = cmd
+		return nil
+	}, WithErrorHandler(func(spec string, err error) {
+		failed = append(failed, spec)
+	}))
+
+	var fired time.Time
+	var ctxErr error
+	expect(t, s.Add("@every 1m", func(now time.Time, ctx context.Context, dep string) {
+		fired = now
+		ctxErr = ctx.Err()
+	}), nil)
+	expect(t, s.Add("@hourly", func() error { return errors.New("job failed") }), nil)
+	expect(t, s.Add("", func() {}) != nil, true)
+	expect(t, s.Add("@daily", "not a function") != nil, true)
+
+	jobs["@every 1m"]()
+	expect(t, fired.IsZero(), false)
+	expect(t, ctxErr, nil)
+
+	jobs["@hourly"]()
+	expect(t, len(failed), 1)
+	expect(t, failed[0], "@hourly")
+
+	s.Stop()
+	jobs["@every 1m"]()
+	expect(t, ctxErr, context.Canceled)
+}
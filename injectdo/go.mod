@@ -0,0 +1,10 @@
+module github.com/juanjiTech/inject/v2/injectdo
+
+go 1.18
+
+require (
+	github.com/juanjiTech/inject/v2 v2.0.0
+	github.com/samber/do v1.6.0
+)
+
+replace github.com/juanjiTech/inject/v2 => ../
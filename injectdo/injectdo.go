@@ -0,0 +1,38 @@
+// Package injectdo bridges Injectors and samber/do containers, so that code
+// using either can resolve the services of the other during a migration
+// instead of registering everything twice.
+//
+// samber/do identifies services by their static type, so bridging is done type
+// by type with FromDo and ToDo. It is a separate module, so that the main
+// module does not depend on samber/do.
+package injectdo
+
+import (
+	"fmt"
+
+	"github.com/juanjiTech/inject/v2"
+	"github.com/samber/do"
+)
+
+// FromDo binds T in inj to the service of type T in di. The service is invoked
+// lazily, the first time T is resolved from inj, and errors of di are
+// returned by the resolution.
+func FromDo[T any](inj inject.TypeMapper, di *do.Injector) error {
+	return inj.Provide(func() (T, error) {
+		return do.Invoke[T](di)
+	})
+}
+
+// ToDo provides the service of type T in di from the binding of T in inj,
+// including its parents. The binding is resolved lazily, the first time the
+// service is invoked from di.
+func ToDo[T any](di *do.Injector, inj inject.Injector) {
+	do.Provide(di, func(*do.Injector) (T, error) {
+		var zero T
+		v := inj.Value(inject.Type[T]())
+		if !v.IsValid() {
+			return zero, fmt.Errorf("%w: %v", inject.ErrValueNotFound, inject.Type[T]())
+		}
+		return v.Interface().(T), nil
+	})
+}
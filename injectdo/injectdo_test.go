@@ -0,0 +1,69 @@
+package injectdo
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/juanjiTech/inject/v2"
+	"github.com/samber/do"
+)
+
+/* Test Helpers */
+func expect(t testing.TB, actual interface{}, expect interface{}) {
+	t.Helper()
+	if actual != expect {
+		t.Errorf("Expected %v (type %v) - Got %v (type %v)", expect, reflect.TypeOf(expect), actual, reflect.TypeOf(actual))
+	}
+}
+
+type name string
+
+func (n name) String() string { return string(n) }
+
+type config struct {
+	dsn string
+}
+
+func TestFromDo(t *testing.T) {
+	di := do.New()
+	calls := 0
+	do.Provide(di, func(*do.Injector) (*config, error) {
+		calls++
+		return &config{dsn: "db"}, nil
+	})
+	do.ProvideValue[fmt.Stringer](di, name("do"))
+
+	inj := inject.New()
+	expect(t, FromDo[*config](inj, di), nil)
+	expect(t, FromDo[fmt.Stringer](inj, di), nil)
+	expect(t, FromDo[int](inj, di), nil)
+	expect(t, calls, 0)
+
+	_, err := inj.Invoke(func(c *config, s fmt.Stringer) {
+		expect(t, c.dsn, "db")
+		expect(t, s.String(), "do")
+	})
+	expect(t, err, nil)
+	expect(t, calls, 1)
+
+	_, err = inj.Invoke(func(int) {})
+	expect(t, err != nil, true)
+}
+
+func TestToDo(t *testing.T) {
+	inj := inject.New()
+	inj.Map(&config{dsn: "inject"})
+	inj.MapTo(name("inject"), (*fmt.Stringer)(nil))
+
+	di := do.New()
+	ToDo[*config](di, inj)
+	ToDo[fmt.Stringer](di, inj)
+	ToDo[int](di, inj)
+
+	expect(t, do.MustInvoke[*config](di).dsn, "inject")
+	expect(t, do.MustInvoke[fmt.Stringer](di).String(), "inject")
+	_, err := do.Invoke[int](di)
+	expect(t, errors.Is(err, inject.ErrValueNotFound), true)
+}
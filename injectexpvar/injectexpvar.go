@@ -0,0 +1,53 @@
+// Package injectexpvar publishes resolution counts of Injectors through
+// expvar, so that existing /debug/vars endpoints show the activity of the
+// injector without wiring a metrics system.
+//
+// It is a separate package, so that programs using the main package do not
+// get the /debug/vars handler registered by importing expvar.
+package injectexpvar
+
+import (
+	"expvar"
+	"reflect"
+	"sync"
+
+	"github.com/juanjiTech/inject/v2"
+)
+
+var mu sync.Mutex
+
+// Publish returns an Option making the Injector and its children count their
+// resolutions by type in the expvar map named name. The map holds the maps
+// "resolutions", "misses" and "errors", keyed by type: resolutions that found
+// a value, that found none, and that failed, e.g. because a provider returned
+// an error. Publishing under the same name again adds to the same counts.
+func Publish(name string) inject.Option {
+	mu.Lock()
+	m, ok := expvar.Get(name).(*expvar.Map)
+	if !ok {
+		m = expvar.NewMap(name)
+	}
+	resolutions, misses, errors := subMap(m, "resolutions"), subMap(m, "misses"), subMap(m, "errors")
+	mu.Unlock()
+
+	return inject.WithResolveHook(func(t reflect.Type, v reflect.Value, err error) {
+		switch {
+		case err != nil:
+			errors.Add(t.String(), 1)
+		case v.IsValid():
+			resolutions.Add(t.String(), 1)
+		default:
+			misses.Add(t.String(), 1)
+		}
+	})
+}
+
+// subMap returns the map named key of m, adding it if needed.
+func subMap(m *expvar.Map, key string) *expvar.Map {
+	if sub, ok := m.Get(key).(*expvar.Map); ok {
+		return sub
+	}
+	sub := new(expvar.Map).Init()
+	m.Set(key, sub)
+	return sub
+}
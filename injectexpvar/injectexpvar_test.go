@@ -0,0 +1,55 @@
+package injectexpvar
+
+import (
+	"encoding/json"
+	"errors"
+	"expvar"
+	"reflect"
+	"testing"
+
+	"github.com/juanjiTech/inject/v2"
+)
+
+func expect(t *testing.T, a interface{}, b interface{}) {
+	t.Helper()
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("Expected %v (type %v) - Got %v (type %v)", b, reflect.TypeOf(b), a, reflect.TypeOf(a))
+	}
+}
+
+type repo struct{}
+
+type counts struct {
+	Resolutions map[string]int64 `json:"resolutions"`
+	Misses      map[string]int64 `json:"misses"`
+	Errors      map[string]int64 `json:"errors"`
+}
+
+func read(t *testing.T, name string) counts {
+	t.Helper()
+	var c counts
+	expect(t, json.Unmarshal([]byte(expvar.Get(name).String()), &c), nil)
+	return c
+}
+
+func TestPublish(t *testing.T) {
+	inj := inject.New(Publish("inject_test"))
+	inj.Map("a dep")
+	expect(t, inj.Provide(func() (*repo, error) { return nil, errors.New("failed") }), nil)
+
+	_, err := inj.Child().Invoke(func(s string) {})
+	expect(t, err, nil)
+	_, _ = inject.LoadT[string](inj)
+	_, _ = inject.LoadT[int](inj)
+	_, _ = inject.LoadT[*repo](inj)
+
+	c := read(t, "inject_test")
+	expect(t, c.Resolutions["string"], int64(2))
+	expect(t, c.Misses["int"], int64(1))
+	expect(t, c.Errors["*injectexpvar.repo"], int64(1))
+
+	again := inject.New(Publish("inject_test"))
+	again.Map("another dep")
+	_, _ = inject.LoadT[string](again)
+	expect(t, read(t, "inject_test").Resolutions["string"], int64(3))
+}
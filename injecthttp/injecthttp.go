@@ -0,0 +1,183 @@
+// Package injecthttp adapts functions with injected arguments to net/http
+// handlers.
+package injecthttp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/juanjiTech/inject/v2"
+)
+
+// PathValues represents the parsed path parameters of a request. It is always
+// mapped into the request scope, and is empty unless a router has attached
+// values to the request with WithPathValues.
+type PathValues map[string]string
+
+type contextKey int
+
+const (
+	injectorKey contextKey = iota
+	pathValuesKey
+)
+
+var (
+	errorType  = reflect.TypeOf((*error)(nil)).Elem()
+	intType    = reflect.TypeOf(0)
+	stringType = reflect.TypeOf("")
+	bytesType  = reflect.TypeOf([]byte(nil))
+)
+
+// Middleware returns a middleware that makes inj the parent of the request
+// scopes created by handlers returned from Handler.
+func Middleware(inj inject.Injector) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), inj)))
+		})
+	}
+}
+
+// NewContext returns a copy of ctx carrying inj.
+func NewContext(ctx context.Context, inj inject.Injector) context.Context {
+	return context.WithValue(ctx, injectorKey, inj)
+}
+
+// FromContext returns the Injector carried by ctx, or nil if there is none.
+func FromContext(ctx context.Context) inject.Injector {
+	inj, _ := ctx.Value(injectorKey).(inject.Injector)
+	return inj
+}
+
+// WithPathValues returns a shallow copy of r carrying the parsed path values.
+func WithPathValues(r *http.Request, values PathValues) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), pathValuesKey, values))
+}
+
+// StatusCoder can be implemented by errors returned from handler functions to
+// choose the response status code. Errors without a status code are written
+// as http.StatusInternalServerError.
+type StatusCoder interface {
+	StatusCode() int
+}
+
+type statusError struct {
+	code int
+	err  error
+}
+
+// Error returns an error that is written as a response with the given status
+// code.
+func Error(code int, err error) error {
+	return &statusError{code: code, err: err}
+}
+
+func (e *statusError) Error() string   { return e.err.Error() }
+func (e *statusError) Unwrap() error   { return e.err }
+func (e *statusError) StatusCode() int { return e.code }
+
+// Handler returns an http.Handler that calls fn for every request. Each request
+// gets its own child scope of the Injector from the request context (see
+// Middleware) in which the *http.Request, http.ResponseWriter, the request
+// context.Context, the request PathValues and the scope itself as
+// inject.Injector are mapped. The scope is ended once fn returns, running the
+// functions registered with OnScopeEnd.
+//
+// The results of fn are translated into the response: an int is used as the
+// status code, a string or []byte is written as the body, and a non-nil error
+// is written as its message with the status code from StatusCoder. It panics
+// if fn is not a function or has other kinds of results.
+func Handler(fn interface{}) http.Handler {
+	h, err := newHandler(fn)
+	if err != nil {
+		panic("injecthttp.Handler: " + err.Error())
+	}
+	return h
+}
+
+func newHandler(fn interface{}) (*handler, error) {
+	t := reflect.TypeOf(fn)
+	if t == nil || t.Kind() != reflect.Func {
+		return nil, fmt.Errorf("%T is not a function", fn)
+	}
+	for i := 0; i < t.NumOut(); i++ {
+		switch out := t.Out(i); out {
+		case errorType, intType, stringType, bytesType:
+		default:
+			return nil, fmt.Errorf("unsupported result type %v", out)
+		}
+	}
+	return &handler{fn: fn}, nil
+}
+
+type handler struct {
+	fn interface{}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	scope := NewScope(w, r)
+	defer scope.End()
+	out, err := scope.Invoke(h.fn)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResults(w, out)
+}
+
+// NewScope returns the child scope Handler uses to invoke functions for r.
+func NewScope(w http.ResponseWriter, r *http.Request) inject.Injector {
+	var scope inject.Injector
+	if parent := FromContext(r.Context()); parent != nil {
+		scope = parent.Child()
+	} else {
+		scope = inject.New()
+	}
+	values, _ := r.Context().Value(pathValuesKey).(PathValues)
+	if values == nil {
+		values = PathValues{}
+	}
+	scope.Map(r, values).
+		MapTo(w, (*http.ResponseWriter)(nil)).
+		MapTo(r.Context(), (*context.Context)(nil)).
+		MapTo(scope, (*inject.Injector)(nil))
+	return scope
+}
+
+func writeResults(w http.ResponseWriter, out []reflect.Value) {
+	code := 0
+	var body []byte
+	for _, v := range out {
+		switch v.Type() {
+		case errorType:
+			if !v.IsNil() {
+				writeError(w, v.Interface().(error))
+				return
+			}
+		case intType:
+			code = int(v.Int())
+		case stringType:
+			body = []byte(v.String())
+		case bytesType:
+			body = v.Bytes()
+		}
+	}
+	if code != 0 {
+		w.WriteHeader(code)
+	}
+	if body != nil {
+		_, _ = w.Write(body)
+	}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+	var sc StatusCoder
+	if errors.As(err, &sc) {
+		code = sc.StatusCode()
+	}
+	http.Error(w, err.Error(), code)
+}
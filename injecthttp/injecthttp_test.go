@@ -0,0 +1,80 @@
+package injecthttp
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/juanjiTech/inject/v2"
+)
+
+/* Test Helpers */
+func expect(t testing.TB, actual interface{}, expect interface{}) {
+	t.Helper()
+	if actual != expect {
+		t.Errorf("Expected %v (type %v) - Got %v (type %v)", expect, reflect.TypeOf(expect), actual, reflect.TypeOf(actual))
+	}
+}
+
+func serve(h http.Handler, r *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, r)
+	return rec
+}
+
+func TestHandler(t *testing.T) {
+	inj := inject.New()
+	inj.Map("world")
+
+	t.Run("results", func(t *testing.T) {
+		h := Middleware(inj)(Handler(func(r *http.Request, name string, pv PathValues) (int, string) {
+			return http.StatusAccepted, r.Method + " hello " + name + pv["id"]
+		}))
+		r := WithPathValues(httptest.NewRequest(http.MethodPost, "/", nil), PathValues{"id": "1"})
+		rec := serve(h, r)
+		expect(t, rec.Code, http.StatusAccepted)
+		expect(t, rec.Body.String(), "POST hello world1")
+	})
+
+	t.Run("writer", func(t *testing.T) {
+		h := Middleware(inj)(Handler(func(w http.ResponseWriter, name string) {
+			_, _ = w.Write([]byte(name))
+		}))
+		rec := serve(h, httptest.NewRequest(http.MethodGet, "/", nil))
+		expect(t, rec.Code, http.StatusOK)
+		expect(t, rec.Body.String(), "world")
+	})
+
+	t.Run("scope end", func(t *testing.T) {
+		ended := false
+		h := Middleware(inj)(Handler(func(scope inject.Injector) string {
+			scope.OnScopeEnd(func() { ended = true })
+			return "tx"
+		}))
+		rec := serve(h, httptest.NewRequest(http.MethodGet, "/", nil))
+		expect(t, rec.Body.String(), "tx")
+		expect(t, ended, true)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		h := Middleware(inj)(Handler(func() ([]byte, error) {
+			return nil, Error(http.StatusTeapot, errors.New("no coffee"))
+		}))
+		rec := serve(h, httptest.NewRequest(http.MethodGet, "/", nil))
+		expect(t, rec.Code, http.StatusTeapot)
+		expect(t, rec.Body.String(), "no coffee\n")
+
+		h = Handler(func(name string) error { return nil })
+		rec = serve(h, httptest.NewRequest(http.MethodGet, "/", nil))
+		expect(t, rec.Code, http.StatusInternalServerError)
+	})
+
+	t.Run("unsupported results", func(t *testing.T) {
+		defer func() {
+			expect(t, recover() != nil, true)
+		}()
+		Handler(func() float64 { return 0 })
+	})
+}
@@ -0,0 +1,126 @@
+package injecthttp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/juanjiTech/inject/v2"
+)
+
+// requestTypes are the types mapped into every request scope by NewScope.
+var requestTypes = map[reflect.Type]bool{
+	reflect.TypeOf((*http.Request)(nil)):               true,
+	reflect.TypeOf((*http.ResponseWriter)(nil)).Elem(): true,
+	reflect.TypeOf((*context.Context)(nil)).Elem():     true,
+	reflect.TypeOf(PathValues(nil)):                    true,
+	reflect.TypeOf((*inject.Injector)(nil)).Elem():     true,
+}
+
+// Router is a minimal request router whose routes are functions with injected
+// arguments. Routes are verified against the Injector when they are registered,
+// so unresolvable arguments are reported at startup instead of per request.
+//
+// Patterns are made of "/" separated segments, where a segment of the form
+// ":name" or "{name}" matches any single path segment and is made available as
+// PathValues["name"].
+type Router struct {
+	inj    inject.Injector
+	routes []*route
+}
+
+type route struct {
+	method   string
+	segments []string
+	handler  *handler
+}
+
+// NewRouter returns a new Router resolving route arguments from inj.
+func NewRouter(inj inject.Injector) *Router {
+	return &Router{inj: inj}
+}
+
+// Handle registers fn for requests matching method and pattern. It returns an
+// error if fn is not a valid handler function (see Handler) or if any of its
+// arguments can neither be resolved from the Injector nor is request scoped.
+func (rt *Router) Handle(method, pattern string, fn interface{}) error {
+	h, err := newHandler(fn)
+	if err != nil {
+		return fmt.Errorf("%s %s: %v", method, pattern, err)
+	}
+	for _, arg := range rt.inj.Explain(fn) {
+		if !arg.Found && !requestTypes[arg.Type] {
+			return fmt.Errorf("%s %s: %w: %v", method, pattern, inject.ErrValueNotFound, arg.Type)
+		}
+	}
+	rt.routes = append(rt.routes, &route{
+		method:   method,
+		segments: splitPath(pattern),
+		handler:  h,
+	})
+	return nil
+}
+
+// ServeHTTP dispatches the request to the first matching route. It responds
+// with http.StatusMethodNotAllowed if only the method does not match, and with
+// http.StatusNotFound if no route matches.
+func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	segments := splitPath(r.URL.Path)
+	var allowed []string
+	for _, rte := range rt.routes {
+		values, ok := rte.match(segments)
+		if !ok {
+			continue
+		}
+		if rte.method != r.Method {
+			allowed = append(allowed, rte.method)
+			continue
+		}
+		r = r.WithContext(NewContext(r.Context(), rt.inj))
+		rte.handler.ServeHTTP(w, WithPathValues(r, values))
+		return
+	}
+	if len(allowed) > 0 {
+		sort.Strings(allowed)
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	http.NotFound(w, r)
+}
+
+func (r *route) match(segments []string) (PathValues, bool) {
+	if len(segments) != len(r.segments) {
+		return nil, false
+	}
+	values := PathValues{}
+	for i, s := range r.segments {
+		if name, ok := paramName(s); ok {
+			values[name] = segments[i]
+		} else if s != segments[i] {
+			return nil, false
+		}
+	}
+	return values, true
+}
+
+func paramName(segment string) (string, bool) {
+	if strings.HasPrefix(segment, ":") {
+		return segment[1:], true
+	}
+	if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+		return segment[1 : len(segment)-1], true
+	}
+	return "", false
+}
+
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
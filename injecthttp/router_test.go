@@ -0,0 +1,53 @@
+package injecthttp
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/juanjiTech/inject/v2"
+)
+
+type userRepo struct {
+	users map[string]string
+}
+
+func TestRouter(t *testing.T) {
+	inj := inject.New()
+	inj.Map(&userRepo{users: map[string]string{"1": "Jeremy"}})
+
+	rt := NewRouter(inj)
+	err := rt.Handle(http.MethodGet, "/users/:id", func(repo *userRepo, pv PathValues) (int, string) {
+		name, ok := repo.users[pv["id"]]
+		if !ok {
+			return http.StatusNotFound, "unknown user"
+		}
+		return http.StatusOK, name
+	})
+	expect(t, err, nil)
+	expect(t, rt.Handle(http.MethodPut, "/users/{id}", func(w http.ResponseWriter) {}), nil)
+
+	rec := serve(rt, httptest.NewRequest(http.MethodGet, "/users/1", nil))
+	expect(t, rec.Code, http.StatusOK)
+	expect(t, rec.Body.String(), "Jeremy")
+
+	rec = serve(rt, httptest.NewRequest(http.MethodGet, "/users/2", nil))
+	expect(t, rec.Code, http.StatusNotFound)
+
+	rec = serve(rt, httptest.NewRequest(http.MethodDelete, "/users/1", nil))
+	expect(t, rec.Code, http.StatusMethodNotAllowed)
+	expect(t, rec.Header().Get("Allow"), "GET, PUT")
+
+	rec = serve(rt, httptest.NewRequest(http.MethodGet, "/posts", nil))
+	expect(t, rec.Code, http.StatusNotFound)
+}
+
+func TestRouter_Handle(t *testing.T) {
+	rt := NewRouter(inject.New())
+	err := rt.Handle(http.MethodGet, "/", func(repo *userRepo) {})
+	expect(t, errors.Is(err, inject.ErrValueNotFound), true)
+
+	err = rt.Handle(http.MethodGet, "/", "not a function")
+	expect(t, err != nil, true)
+}
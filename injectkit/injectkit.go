@@ -0,0 +1,67 @@
+// Package injectkit turns functions with injected arguments into go-kit
+// endpoints, so that business functions resolve their service dependencies
+// from an Injector while decoding requests and encoding responses remains the
+// concern of the go-kit transport.
+//
+// It does not depend on go-kit: an Endpoint is assignable to endpoint.Endpoint
+// of github.com/go-kit/kit/endpoint and can be wrapped by its middlewares.
+package injectkit
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/juanjiTech/inject/v2"
+)
+
+// Endpoint is the signature of endpoint.Endpoint of go-kit.
+type Endpoint = func(ctx context.Context, request interface{}) (response interface{}, err error)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// New returns an Endpoint that invokes fn in a new child scope of inj for
+// every request. The decoded request is mapped into the scope by its concrete
+// type, and ctx as context.Context; the scope is ended once fn returns.
+//
+// fn may return a response, an error, or a response followed by an error. A
+// non-nil error is returned by the endpoint, as is an error resolving the
+// arguments of fn. It returns an error if fn is not a function or has other
+// results.
+func New(inj inject.Injector, fn interface{}) (Endpoint, error) {
+	t := reflect.TypeOf(fn)
+	if t == nil || t.Kind() != reflect.Func {
+		return nil, fmt.Errorf("injectkit: %T is not a function", fn)
+	}
+	errIndex := -1
+	switch n := t.NumOut(); {
+	case n == 2 && t.Out(1) == errorType:
+		errIndex = 1
+	case n == 1 && t.Out(0) == errorType:
+		errIndex = 0
+	case n > 1:
+		return nil, fmt.Errorf("injectkit: %v must return a response, an error or both", t)
+	}
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		scope := inj.Child()
+		defer scope.End()
+		if request != nil {
+			scope.Map(request)
+		}
+		scope.MapTo(ctx, (*context.Context)(nil))
+
+		out, err := scope.Invoke(fn)
+		if err != nil {
+			return nil, err
+		}
+		if errIndex >= 0 {
+			if err, _ := out[errIndex].Interface().(error); err != nil {
+				return nil, err
+			}
+		}
+		if len(out) == 0 || errIndex == 0 {
+			return nil, nil
+		}
+		return out[0].Interface(), nil
+	}, nil
+}
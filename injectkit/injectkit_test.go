@@ -0,0 +1,75 @@
+package injectkit
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/juanjiTech/inject/v2"
+)
+
+func expect(t *testing.T, a interface{}, b interface{}) {
+	t.Helper()
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("Expected %v (type %v) - Got %v (type %v)", b, reflect.TypeOf(b), a, reflect.TypeOf(a))
+	}
+}
+
+type greetRequest struct{ Name string }
+
+type greetResponse struct{ Greeting string }
+
+type greeter struct{ prefix string }
+
+type ctxKey struct{}
+
+func TestNew(t *testing.T) {
+	inj := inject.New()
+	inj.Map(&greeter{prefix: "Hello, "})
+
+	ep, err := New(inj, func(ctx context.Context, g *greeter, req greetRequest) (greetResponse, error) {
+		expect(t, ctx.Value(ctxKey{}), "traced")
+		if req.Name == "" {
+			return greetResponse{}, errors.New("no name")
+		}
+		return greetResponse{Greeting: g.prefix + req.Name}, nil
+	})
+	expect(t, err, nil)
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "traced")
+	resp, err := ep(ctx, greetRequest{Name: "Jeremy"})
+	expect(t, err, nil)
+	expect(t, resp, greetResponse{Greeting: "Hello, Jeremy"})
+
+	resp, err = ep(ctx, greetRequest{})
+	expect(t, err.Error(), "no name")
+	expect(t, resp, nil)
+}
+
+func TestNew_Results(t *testing.T) {
+	inj := inject.New()
+	ep, err := New(inj, func() {})
+	expect(t, err, nil)
+	resp, err := ep(context.Background(), nil)
+	expect(t, resp, nil)
+	expect(t, err, nil)
+
+	ep, err = New(inj, func() error { return errors.New("failed") })
+	expect(t, err, nil)
+	_, err = ep(context.Background(), nil)
+	expect(t, err.Error(), "failed")
+
+	ep, err = New(inj, func(n int) int { return n * 2 })
+	expect(t, err, nil)
+	resp, _ = ep(context.Background(), 21)
+	expect(t, resp, 42)
+
+	_, err = ep(context.Background(), "not an int")
+	expect(t, errors.Is(err, inject.ErrValueNotFound), true)
+
+	_, err = New(inj, func() (int, int) { return 0, 0 })
+	expect(t, err != nil, true)
+	_, err = New(inj, "not a function")
+	expect(t, err != nil, true)
+}
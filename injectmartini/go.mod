@@ -0,0 +1,10 @@
+module github.com/juanjiTech/inject/v2/injectmartini
+
+go 1.18
+
+require (
+	github.com/codegangsta/inject v0.0.0-20150114235600-33e0aa1cb7c0
+	github.com/juanjiTech/inject/v2 v2.0.0
+)
+
+replace github.com/juanjiTech/inject/v2 => ../
@@ -0,0 +1,82 @@
+// Package injectmartini adapts Injectors to the classic inject.Injector
+// interface of github.com/codegangsta/inject used by martini and macaron, and
+// the other way around, so legacy middleware can run on this injector during
+// a migration.
+//
+// It is a separate module, so that the main module does not depend on
+// codegangsta/inject.
+package injectmartini
+
+import (
+	"reflect"
+
+	legacy "github.com/codegangsta/inject"
+	"github.com/juanjiTech/inject/v2"
+)
+
+// ToLegacy returns inj as a legacy inject.Injector. Values mapped through it
+// are mapped into inj, and lookups resolve from inj and its parents.
+func ToLegacy(inj inject.Injector) legacy.Injector {
+	return &legacyInjector{inj: inj}
+}
+
+type legacyInjector struct {
+	inj inject.Injector
+}
+
+func (l *legacyInjector) Apply(val interface{}) error {
+	return l.inj.Apply(val)
+}
+
+func (l *legacyInjector) Invoke(f interface{}) ([]reflect.Value, error) {
+	return l.inj.Invoke(f)
+}
+
+func (l *legacyInjector) Map(val interface{}) legacy.TypeMapper {
+	l.inj.Map(val)
+	return l
+}
+
+func (l *legacyInjector) MapTo(val interface{}, ifacePtr interface{}) legacy.TypeMapper {
+	l.inj.MapTo(val, ifacePtr)
+	return l
+}
+
+func (l *legacyInjector) Set(typ reflect.Type, val reflect.Value) legacy.TypeMapper {
+	l.inj.Set(typ, val)
+	return l
+}
+
+func (l *legacyInjector) Get(t reflect.Type) reflect.Value {
+	return l.inj.Value(t)
+}
+
+// SetParent sets the parent of the underlying Injector, see FromLegacy.
+func (l *legacyInjector) SetParent(parent legacy.Injector) {
+	l.inj.SetParent(FromLegacy(parent))
+}
+
+// FromLegacy returns an Injector resolving from the legacy injector l. It is
+// meant to be used as a parent, e.g. inject.New().SetParent(FromLegacy(l)),
+// so that the bindings of l are found by lookups that the child cannot
+// satisfy. Values mapped into the returned Injector are not visible to l.
+func FromLegacy(l legacy.Injector) inject.Injector {
+	if li, ok := l.(*legacyInjector); ok {
+		return li.inj
+	}
+	return &fromLegacy{Injector: inject.New(), legacy: l}
+}
+
+type fromLegacy struct {
+	inject.Injector
+	legacy legacy.Injector
+}
+
+// Value returns the value mapped to t in the Injector itself, or else in the
+// legacy injector.
+func (f *fromLegacy) Value(t reflect.Type) reflect.Value {
+	if v := f.Injector.Value(t); v.IsValid() {
+		return v
+	}
+	return f.legacy.Get(t)
+}
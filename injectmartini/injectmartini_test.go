@@ -0,0 +1,66 @@
+package injectmartini
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	legacy "github.com/codegangsta/inject"
+	"github.com/juanjiTech/inject/v2"
+)
+
+/* Test Helpers */
+func expect(t testing.TB, actual interface{}, expect interface{}) {
+	t.Helper()
+	if actual != expect {
+		t.Errorf("Expected %v (type %v) - Got %v (type %v)", expect, reflect.TypeOf(expect), actual, reflect.TypeOf(actual))
+	}
+}
+
+type name string
+
+func (n name) String() string { return string(n) }
+
+func TestToLegacy(t *testing.T) {
+	inj := inject.New()
+	inj.Map(1)
+	l := ToLegacy(inj)
+	l.Map("legacy").MapTo(name("martini"), (*fmt.Stringer)(nil))
+
+	_, err := l.Invoke(func(i int, s string, st fmt.Stringer) {
+		expect(t, i, 1)
+		expect(t, s, "legacy")
+		expect(t, st.String(), "martini")
+	})
+	expect(t, err, nil)
+	expect(t, inj.Value(reflect.TypeOf("")).String(), "legacy")
+	expect(t, l.Get(reflect.TypeOf(1)).Interface(), 1)
+
+	var s struct {
+		S string `inject:""`
+	}
+	expect(t, l.Apply(&s), nil)
+	expect(t, s.S, "legacy")
+
+	parent := legacy.New()
+	parent.Map(true)
+	l.SetParent(parent)
+	expect(t, inj.Value(reflect.TypeOf(true)).Bool(), true)
+}
+
+func TestFromLegacy(t *testing.T) {
+	l := legacy.New()
+	l.Map("legacy")
+	inj := inject.New()
+	inj.SetParent(FromLegacy(l))
+	inj.Map(1)
+
+	_, err := inj.Invoke(func(i int, s string) {
+		expect(t, i, 1)
+		expect(t, s, "legacy")
+	})
+	expect(t, err, nil)
+
+	adapted := inject.New()
+	expect(t, FromLegacy(ToLegacy(adapted)), adapted)
+}
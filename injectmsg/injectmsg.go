@@ -0,0 +1,150 @@
+// Package injectmsg dispatches messages from brokers such as Kafka or NATS to
+// handler functions with injected arguments.
+//
+// Broker clients are adapted by implementing Message for their message type
+// and calling Consumer.Dispatch from their receive loop.
+package injectmsg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/juanjiTech/inject/v2"
+)
+
+// ErrNoHandler is returned by Dispatch for messages of a topic without handler.
+var ErrNoHandler = errors.New("no handler for topic")
+
+// Message represents a message received from a broker.
+type Message interface {
+	// Topic returns the topic (or subject) the message was received from.
+	Topic() string
+	// Payload returns the message body.
+	Payload() []byte
+}
+
+// Acknowledger can be implemented by messages that need to be acknowledged to
+// the broker. It is used by the default Hooks.
+type Acknowledger interface {
+	// Ack acknowledges the successful processing of the message.
+	Ack() error
+	// Nack reports that processing the message failed with err.
+	Nack(err error) error
+}
+
+// Hooks customizes how the outcome of a handler is reported to the broker. A
+// nil hook falls back to Acknowledger, if the message implements it.
+type Hooks struct {
+	// OnSuccess is called after a handler returned without error.
+	OnSuccess func(ctx context.Context, msg Message) error
+	// OnError is called with the injection error or the non-nil error returned
+	// as the last result of a handler.
+	OnError func(ctx context.Context, msg Message, err error) error
+}
+
+// Option configures a Consumer created by New.
+type Option func(*Consumer)
+
+// WithHooks sets the Hooks of the Consumer.
+func WithHooks(h Hooks) Option {
+	return func(c *Consumer) {
+		c.hooks = h
+	}
+}
+
+// Consumer routes messages to injected handler functions by topic.
+type Consumer struct {
+	inj      inject.Injector
+	hooks    Hooks
+	mu       sync.RWMutex
+	handlers map[string]interface{}
+}
+
+// New returns a Consumer resolving handler arguments from inj.
+func New(inj inject.Injector, opts ...Option) *Consumer {
+	c := &Consumer{
+		inj:      inj,
+		handlers: make(map[string]interface{}),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Handle registers fn as the handler for messages of topic, replacing any
+// previous handler. It returns an error if fn is not a function.
+func (c *Consumer) Handle(topic string, fn interface{}) error {
+	if t := reflect.TypeOf(fn); t == nil || t.Kind() != reflect.Func {
+		return fmt.Errorf("injectmsg: handler for %q is %T, not a function", topic, fn)
+	}
+	c.mu.Lock()
+	c.handlers[topic] = fn
+	c.mu.Unlock()
+	return nil
+}
+
+// Dispatch invokes the handler of the message's topic in a new child scope of
+// the Injector, in which msg is mapped both as Message and as its concrete
+// type, and ctx is mapped as context.Context. The scope is ended once the
+// handler returns. The outcome is reported through the Hooks, and the handler
+// error, if any, is returned, also if reporting it fails.
+func (c *Consumer) Dispatch(ctx context.Context, msg Message) error {
+	c.mu.RLock()
+	fn, ok := c.handlers[msg.Topic()]
+	c.mu.RUnlock()
+
+	var err error
+	if !ok {
+		err = fmt.Errorf("%w: %s", ErrNoHandler, msg.Topic())
+	} else {
+		err = c.invoke(ctx, msg, fn)
+	}
+	if err != nil {
+		if hookErr := c.onError(ctx, msg, err); hookErr != nil {
+			return fmt.Errorf("%w (reporting failed: %v)", err, hookErr)
+		}
+		return err
+	}
+	return c.onSuccess(ctx, msg)
+}
+
+func (c *Consumer) invoke(ctx context.Context, msg Message, fn interface{}) error {
+	scope := c.inj.Child()
+	defer scope.End()
+	scope.Map(msg).
+		MapTo(msg, (*Message)(nil)).
+		MapTo(ctx, (*context.Context)(nil))
+
+	out, err := scope.Invoke(fn)
+	if err != nil {
+		return err
+	}
+	if len(out) > 0 {
+		err, _ = out[len(out)-1].Interface().(error)
+	}
+	return err
+}
+
+func (c *Consumer) onSuccess(ctx context.Context, msg Message) error {
+	if c.hooks.OnSuccess != nil {
+		return c.hooks.OnSuccess(ctx, msg)
+	}
+	if a, ok := msg.(Acknowledger); ok {
+		return a.Ack()
+	}
+	return nil
+}
+
+func (c *Consumer) onError(ctx context.Context, msg Message, err error) error {
+	if c.hooks.OnError != nil {
+		return c.hooks.OnError(ctx, msg, err)
+	}
+	if a, ok := msg.(Acknowledger); ok {
+		return a.Nack(err)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package injectmsg
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/juanjiTech/inject/v2"
+)
+
+/* Test Helpers */
+func expect(t testing.TB, actual interface{}, expect interface{}) {
+	t.Helper()
+	if actual != expect {
+		t.Errorf("Expected %v (type %v) - Got %v (type %v)", expect, reflect.TypeOf(expect), actual, reflect.TypeOf(actual))
+	}
+}
+
+type testMessage struct {
+	topic   string
+	payload string
+	acked   bool
+	nacked  error
+}
+
+func (m *testMessage) Topic() string   { return m.topic }
+func (m *testMessage) Payload() []byte { return []byte(m.payload) }
+func (m *testMessage) Ack() error      { m.acked = true; return nil }
+func (m *testMessage) Nack(err error) error {
+	m.nacked = err
+	return nil
+}
+
+type orderRepo struct {
+	orders []string
+}
+
+func TestConsumer_Dispatch(t *testing.T) {
+	repo := &orderRepo{}
+	inj := inject.New()
+	inj.Map(repo)
+
+	c := New(inj)
+	expect(t, c.Handle("orders", func(msg Message, repo *orderRepo, ctx context.Context) error {
+		if msg.Payload() == nil {
+			return errors.New("empty order")
+		}
+		repo.orders = append(repo.orders, string(msg.Payload()))
+		return nil
+	}), nil)
+	expect(t, c.Handle("raw", func(msg *testMessage) {}), nil)
+	expect(t, c.Handle("bad", "not a function") != nil, true)
+
+	msg := &testMessage{topic: "orders", payload: "order-1"}
+	expect(t, c.Dispatch(context.Background(), msg), nil)
+	expect(t, msg.acked, true)
+	expect(t, len(repo.orders), 1)
+
+	msg = &testMessage{topic: "raw"}
+	expect(t, c.Dispatch(context.Background(), msg), nil)
+	expect(t, msg.acked, true)
+
+	msg = &testMessage{topic: "unknown"}
+	err := c.Dispatch(context.Background(), msg)
+	expect(t, errors.Is(err, ErrNoHandler), true)
+	expect(t, msg.nacked, err)
+}
+
+func TestConsumer_Hooks(t *testing.T) {
+	var reported error
+	c := New(inject.New(), WithHooks(Hooks{
+		OnError: func(ctx context.Context, msg Message, err error) error {
+			reported = err
+			return nil
+		},
+	}))
+	expect(t, c.Handle("orders", func(repo *orderRepo) {}), nil)
+
+	msg := &testMessage{topic: "orders"}
+	err := c.Dispatch(context.Background(), msg)
+	expect(t, errors.Is(err, inject.ErrValueNotFound), true)
+	expect(t, reported, err)
+	expect(t, msg.nacked, nil)
+}
+
+func TestConsumer_HookFails(t *testing.T) {
+	hookErr := errors.New("broker unavailable")
+	c := New(inject.New(), WithHooks(Hooks{
+		OnError: func(ctx context.Context, msg Message, err error) error {
+			return hookErr
+		},
+	}))
+
+	err := c.Dispatch(context.Background(), &testMessage{topic: "payments"})
+	expect(t, errors.Is(err, ErrNoHandler), true)
+	expect(t, strings.Contains(err.Error(), hookErr.Error()), true)
+}
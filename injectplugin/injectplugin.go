@@ -0,0 +1,263 @@
+// Package injectplugin loads plugins into isolated injectors. Every plugin
+// gets its own Injector that sees only the host bindings the Loader exposes,
+// contributes values to named groups the host collects across plugins, and
+// can be unloaded again, which ends its scope and withdraws its
+// contributions.
+//
+// Plugins are either Go plugins opened with Loader.Open, or factories
+// registered with Register by packages linked into the host binary.
+package injectplugin
+
+import (
+	"errors"
+	"fmt"
+	"plugin"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/juanjiTech/inject/v2"
+)
+
+// SetupSymbol is the name of the function a Go plugin exports for
+// Loader.Open. It must be a Factory, declared as
+//
+//	func Setup(p *injectplugin.Plugin) error
+const SetupSymbol = "Setup"
+
+// Factory sets up a plugin: it binds the plugin's own types in p.Scope,
+// contributes values with p.Contribute and registers cleanups with
+// p.Scope.OnScopeEnd.
+type Factory func(p *Plugin) error
+
+var (
+	// ErrNotRegistered is returned by Loader.Load for a name no factory is
+	// registered for.
+	ErrNotRegistered = errors.New("injectplugin: plugin not registered")
+	// ErrLoaded is returned when loading a plugin under a name that is
+	// already loaded.
+	ErrLoaded = errors.New("injectplugin: plugin already loaded")
+	// ErrNotLoaded is returned by Loader.Unload for a name that is not loaded.
+	ErrNotLoaded = errors.New("injectplugin: plugin not loaded")
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+var registry = struct {
+	sync.RWMutex
+	factories map[string]Factory
+}{factories: make(map[string]Factory)}
+
+// Register makes the factory available to Loader.Load under name. It is
+// meant to be called from the init function of the plugin's package, and
+// panics if name is already registered or factory is nil.
+func Register(name string, factory Factory) {
+	if factory == nil {
+		panic("injectplugin: Register of nil factory for " + name)
+	}
+	registry.Lock()
+	defer registry.Unlock()
+	if _, ok := registry.factories[name]; ok {
+		panic("injectplugin: Register called twice for " + name)
+	}
+	registry.factories[name] = factory
+}
+
+// Plugin is a loaded plugin.
+type Plugin struct {
+	// Name is the name the plugin was loaded under.
+	Name string
+	// Scope is the plugin's own Injector. Its parent is not the host: only
+	// the types exposed by the Loader resolve from the host, every other
+	// type must be bound by the plugin.
+	Scope inject.Injector
+
+	mu            sync.Mutex
+	contributions map[string][]interface{}
+}
+
+// Contribute adds values to the named group, which the host reads across
+// all loaded plugins with Loader.Group.
+func (p *Plugin) Contribute(group string, values ...interface{}) {
+	p.mu.Lock()
+	if p.contributions == nil {
+		p.contributions = make(map[string][]interface{})
+	}
+	p.contributions[group] = append(p.contributions[group], values...)
+	p.mu.Unlock()
+}
+
+// group returns a copy of the values p contributed to group.
+func (p *Plugin) group(group string) []interface{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]interface{}(nil), p.contributions[group]...)
+}
+
+// Loader loads plugins exposing a whitelist of the bindings of a host
+// Injector.
+type Loader struct {
+	host   inject.Injector
+	expose []reflect.Type
+
+	mu      sync.RWMutex
+	plugins map[string]*Plugin
+	order   []string
+}
+
+// NewLoader returns a Loader whose plugins can resolve the exposed types from
+// host. Exposed types are resolved from host on every resolution in a plugin,
+// so they follow rebinding in host and fail like host does when unbound.
+func NewLoader(host inject.Injector, expose ...reflect.Type) *Loader {
+	return &Loader{
+		host:    host,
+		expose:  append([]reflect.Type(nil), expose...),
+		plugins: make(map[string]*Plugin),
+	}
+}
+
+// Load loads the plugin whose factory is registered under name.
+func (l *Loader) Load(name string) (*Plugin, error) {
+	registry.RLock()
+	factory, ok := registry.factories[name]
+	registry.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotRegistered, name)
+	}
+	return l.LoadFactory(name, factory)
+}
+
+// Open opens the Go plugin at path and loads it under name, setting it up
+// with the function it exports as SetupSymbol. The Go runtime cannot unload
+// the code of a Go plugin: Unload ends its scope and withdraws its
+// contributions, but opening the same path again reuses the loaded code.
+func (l *Loader) Open(name, path string) (*Plugin, error) {
+	lib, err := plugin.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("injectplugin: open %s: %w", path, err)
+	}
+	sym, err := lib.Lookup(SetupSymbol)
+	if err != nil {
+		return nil, fmt.Errorf("injectplugin: %s: %w", path, err)
+	}
+	switch setup := sym.(type) {
+	case func(*Plugin) error:
+		return l.LoadFactory(name, setup)
+	case *Factory:
+		return l.LoadFactory(name, *setup)
+	default:
+		return nil, fmt.Errorf("injectplugin: %s: %s is %T, not a Factory", path, SetupSymbol, sym)
+	}
+}
+
+// LoadFactory loads a plugin set up by factory under name. If factory fails,
+// the plugin's scope is ended and the error is returned.
+func (l *Loader) LoadFactory(name string, factory Factory) (*Plugin, error) {
+	l.mu.RLock()
+	_, loaded := l.plugins[name]
+	l.mu.RUnlock()
+	if loaded {
+		return nil, fmt.Errorf("%w: %s", ErrLoaded, name)
+	}
+
+	scope := inject.New()
+	for _, t := range l.expose {
+		if err := scope.Provide(l.forward(t), inject.WithLifetime(inject.Prototype)); err != nil {
+			return nil, fmt.Errorf("injectplugin: expose %v to %s: %w", t, name, err)
+		}
+	}
+	p := &Plugin{Name: name, Scope: scope}
+	if err := factory(p); err != nil {
+		scope.End()
+		return nil, fmt.Errorf("injectplugin: set up %s: %w", name, err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.plugins[name]; ok {
+		scope.End()
+		return nil, fmt.Errorf("%w: %s", ErrLoaded, name)
+	}
+	l.plugins[name] = p
+	l.order = append(l.order, name)
+	return p, nil
+}
+
+// forward returns a constructor of t resolving it from the host.
+func (l *Loader) forward(t reflect.Type) interface{} {
+	identity := reflect.MakeFunc(reflect.FuncOf([]reflect.Type{t}, []reflect.Type{t}, false),
+		func(in []reflect.Value) []reflect.Value { return in }).Interface()
+	ft := reflect.FuncOf(nil, []reflect.Type{t, errorType}, false)
+	return reflect.MakeFunc(ft, func([]reflect.Value) []reflect.Value {
+		out, err := l.host.Invoke(identity)
+		if err != nil {
+			return []reflect.Value{reflect.Zero(t), reflect.ValueOf(&err).Elem()}
+		}
+		return []reflect.Value{out[0], reflect.Zero(errorType)}
+	}).Interface()
+}
+
+// Unload ends the scope of the named plugin, running the cleanups it
+// registered, and withdraws its contributions.
+func (l *Loader) Unload(name string) error {
+	l.mu.Lock()
+	p, ok := l.plugins[name]
+	if ok {
+		delete(l.plugins, name)
+		for i, n := range l.order {
+			if n == name {
+				l.order = append(l.order[:i:i], l.order[i+1:]...)
+				break
+			}
+		}
+	}
+	l.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNotLoaded, name)
+	}
+	p.Scope.End()
+	return nil
+}
+
+// Close unloads every plugin, the last loaded first.
+func (l *Loader) Close() {
+	l.mu.RLock()
+	names := append([]string(nil), l.order...)
+	l.mu.RUnlock()
+	for i := len(names) - 1; i >= 0; i-- {
+		_ = l.Unload(names[i])
+	}
+}
+
+// Plugin returns the named plugin if it is loaded.
+func (l *Loader) Plugin(name string) (*Plugin, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	p, ok := l.plugins[name]
+	return p, ok
+}
+
+// Plugins returns the names of the loaded plugins, sorted.
+func (l *Loader) Plugins() []string {
+	l.mu.RLock()
+	names := append([]string(nil), l.order...)
+	l.mu.RUnlock()
+	sort.Strings(names)
+	return names
+}
+
+// Group returns the values the loaded plugins contributed to the named
+// group, in the order the plugins were loaded.
+func (l *Loader) Group(group string) []interface{} {
+	l.mu.RLock()
+	plugins := make([]*Plugin, len(l.order))
+	for i, name := range l.order {
+		plugins[i] = l.plugins[name]
+	}
+	l.mu.RUnlock()
+	var values []interface{}
+	for _, p := range plugins {
+		values = append(values, p.group(group)...)
+	}
+	return values
+}
@@ -0,0 +1,105 @@
+package injectplugin
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/juanjiTech/inject/v2"
+)
+
+func expect(t *testing.T, a interface{}, b interface{}) {
+	t.Helper()
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("Expected %v (type %v) - Got %v (type %v)", b, reflect.TypeOf(b), a, reflect.TypeOf(a))
+	}
+}
+
+type config struct{ Env string }
+
+type secrets struct{ Token string }
+
+type route string
+
+func TestLoader(t *testing.T) {
+	host := inject.New()
+	host.Map(&config{Env: "prod"}, &secrets{Token: "hunter2"})
+
+	l := NewLoader(host, inject.Type[*config]())
+	var cleaned []string
+	p, err := l.LoadFactory("users", func(p *Plugin) error {
+		p.Scope.OnScopeEnd(func() { cleaned = append(cleaned, p.Name) })
+		cfg, err := inject.LoadT[*config](p.Scope)
+		if err != nil {
+			return err
+		}
+		p.Contribute("routes", route("/users/"+cfg.Env))
+		return nil
+	})
+	expect(t, err, nil)
+	expect(t, p.Name, "users")
+
+	// Only exposed host bindings are visible, and they follow the host.
+	_, err = inject.LoadT[*secrets](p.Scope)
+	expect(t, errors.Is(err, inject.ErrValueNotFound), true)
+	host.Map(&config{Env: "staging"})
+	cfg, err := inject.LoadT[*config](p.Scope)
+	expect(t, err, nil)
+	expect(t, cfg.Env, "staging")
+
+	_, err = l.LoadFactory("orders", func(p *Plugin) error {
+		p.Contribute("routes", route("/orders"), route("/orders/{id}"))
+		return nil
+	})
+	expect(t, err, nil)
+	expect(t, l.Plugins(), []string{"orders", "users"})
+	expect(t, l.Group("routes"), []interface{}{route("/users/prod"), route("/orders"), route("/orders/{id}")})
+
+	_, err = l.LoadFactory("users", func(*Plugin) error { return nil })
+	expect(t, errors.Is(err, ErrLoaded), true)
+
+	expect(t, l.Unload("users"), nil)
+	expect(t, cleaned, []string{"users"})
+	expect(t, l.Group("routes"), []interface{}{route("/orders"), route("/orders/{id}")})
+	_, ok := l.Plugin("users")
+	expect(t, ok, false)
+	expect(t, errors.Is(l.Unload("users"), ErrNotLoaded), true)
+
+	l.Close()
+	expect(t, len(l.Plugins()), 0)
+	expect(t, len(l.Group("routes")), 0)
+}
+
+func TestLoader_SetupError(t *testing.T) {
+	l := NewLoader(inject.New())
+	ended := false
+	_, err := l.LoadFactory("broken", func(p *Plugin) error {
+		p.Scope.OnScopeEnd(func() { ended = true })
+		return errors.New("no database")
+	})
+	expect(t, err.Error(), "injectplugin: set up broken: no database")
+	expect(t, ended, true)
+	expect(t, len(l.Plugins()), 0)
+}
+
+func TestRegister(t *testing.T) {
+	Register("test/registered", func(p *Plugin) error {
+		p.Contribute("names", p.Name)
+		return nil
+	})
+	l := NewLoader(inject.New())
+	_, err := l.Load("test/registered")
+	expect(t, err, nil)
+	expect(t, l.Group("names"), []interface{}{"test/registered"})
+
+	_, err = l.Load("test/unknown")
+	expect(t, errors.Is(err, ErrNotRegistered), true)
+
+	defer func() { expect(t, recover() != nil, true) }()
+	Register("test/registered", func(*Plugin) error { return nil })
+}
+
+func TestOpen(t *testing.T) {
+	_, err := NewLoader(inject.New()).Open("missing", "testdata/missing.so")
+	expect(t, err != nil, true)
+}
@@ -0,0 +1,156 @@
+// Package injectpool provides a fixed-size pool of goroutines that invoke job
+// functions with injected arguments.
+package injectpool
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/juanjiTech/inject/v2"
+)
+
+var (
+	// ErrClosed is returned by Submit after the Pool has been closed.
+	ErrClosed = errors.New("pool closed")
+	// ErrPanicked is matched by the errors reported to the error handler for
+	// jobs that panicked. The worker recovers and goes on with the next job.
+	ErrPanicked = errors.New("job panicked")
+)
+
+// Option configures a Pool created by New.
+type Option func(*Pool)
+
+// WithWorkerScope gives every worker its own child scope of the Injector,
+// prepared once by setup when the worker starts. Jobs run by the worker are
+// invoked through that scope, which is ended when the worker stops.
+func WithWorkerScope(setup func(worker int, scope inject.TypeMapper)) Option {
+	return func(p *Pool) {
+		p.setup = setup
+	}
+}
+
+// WithErrorHandler sets the function called with errors of failed jobs: the
+// injection error if the job could not be invoked, the non-nil error returned
+// as the job's last result, or an error matching ErrPanicked if the job
+// panicked. Errors are dropped by default.
+func WithErrorHandler(fn func(err error)) Option {
+	return func(p *Pool) {
+		p.onError = fn
+	}
+}
+
+// WithQueueSize sets the number of submitted jobs that can wait for a free
+// worker before Submit blocks. It defaults to the number of workers.
+func WithQueueSize(n int) Option {
+	return func(p *Pool) {
+		p.queueSize = n
+	}
+}
+
+// Pool invokes submitted jobs on a fixed number of goroutines.
+type Pool struct {
+	inj       inject.Injector
+	setup     func(worker int, scope inject.TypeMapper)
+	onError   func(err error)
+	queueSize int
+
+	jobs chan interface{}
+	wg   sync.WaitGroup
+	// done is closed by Close, to release the Submit calls waiting for room
+	// in the queue. pending counts the Submit calls that may still queue a
+	// job, which Close waits for before closing jobs.
+	done      chan struct{}
+	pending   sync.WaitGroup
+	closeJobs sync.Once
+	mu        sync.Mutex
+	closed    bool
+}
+
+// New starts a Pool of workers goroutines invoking jobs through inj.
+func New(inj inject.Injector, workers int, opts ...Option) *Pool {
+	if workers < 1 {
+		workers = 1
+	}
+	p := &Pool{
+		inj:       inj,
+		queueSize: workers,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	p.jobs = make(chan interface{}, p.queueSize)
+	p.done = make(chan struct{})
+	p.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go p.work(i)
+	}
+	return p
+}
+
+// Submit queues job to be invoked by the next free worker, blocking while the
+// queue is full. job must be a function or an inject.FastInvoker; if its last
+// result is an error, a non-nil value is reported to the error handler. A
+// Submit blocked when the Pool is closed returns ErrClosed.
+func (p *Pool) Submit(job interface{}) error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return ErrClosed
+	}
+	p.pending.Add(1)
+	p.mu.Unlock()
+	defer p.pending.Done()
+
+	select {
+	case p.jobs <- job:
+		return nil
+	case <-p.done:
+		return ErrClosed
+	}
+}
+
+// Close stops accepting jobs and waits for the queued jobs to finish.
+func (p *Pool) Close() {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.done)
+	}
+	p.mu.Unlock()
+	p.pending.Wait()
+	p.closeJobs.Do(func() { close(p.jobs) })
+	p.wg.Wait()
+}
+
+func (p *Pool) work(id int) {
+	defer p.wg.Done()
+
+	inj := p.inj
+	if p.setup != nil {
+		scope := p.inj.Child()
+		defer scope.End()
+		p.setup(id, scope)
+		inj = scope
+	}
+	for job := range p.jobs {
+		if err := run(inj, job); err != nil && p.onError != nil {
+			p.onError(err)
+		}
+	}
+}
+
+// run invokes job through inj, returning its error or an error matching
+// ErrPanicked if it panicked.
+func run(inj inject.Injector, job interface{}) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", ErrPanicked, r)
+		}
+	}()
+	out, err := inj.Invoke(job)
+	if err == nil && len(out) > 0 {
+		err, _ = out[len(out)-1].Interface().(error)
+	}
+	return err
+}
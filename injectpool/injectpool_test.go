@@ -0,0 +1,96 @@
+package injectpool
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/juanjiTech/inject/v2"
+)
+
+/* Test Helpers */
+func expect(t testing.TB, actual interface{}, expect interface{}) {
+	t.Helper()
+	if actual != expect {
+		t.Errorf("Expected %v (type %v) - Got %v (type %v)", expect, reflect.TypeOf(expect), actual, reflect.TypeOf(actual))
+	}
+}
+
+func TestPool(t *testing.T) {
+	inj := inject.New()
+	var counter int64
+	inj.Map(&counter)
+
+	var mu sync.Mutex
+	var errs []error
+	p := New(inj, 4,
+		WithWorkerScope(func(worker int, scope inject.TypeMapper) {
+			scope.Map(worker)
+		}),
+		WithErrorHandler(func(err error) {
+			mu.Lock()
+			errs = append(errs, err)
+			mu.Unlock()
+		}),
+	)
+
+	workers := make([]int64, 4)
+	for i := 0; i < 100; i++ {
+		expect(t, p.Submit(func(c *int64, worker int) {
+			atomic.AddInt64(c, 1)
+			atomic.AddInt64(&workers[worker], 1)
+		}), nil)
+	}
+	expect(t, p.Submit(func() error { return errors.New("job failed") }), nil)
+	expect(t, p.Submit(func(string) {}), nil)
+	p.Close()
+
+	expect(t, atomic.LoadInt64(&counter), int64(100))
+	var total int64
+	for _, n := range workers {
+		total += n
+	}
+	expect(t, total, int64(100))
+	expect(t, len(errs), 2)
+
+	expect(t, p.Submit(func() {}), ErrClosed)
+}
+
+func TestPool_PanicAndClose(t *testing.T) {
+	var mu sync.Mutex
+	var errs []error
+	p := New(inject.New(), 1, WithQueueSize(1), WithErrorHandler(func(err error) {
+		mu.Lock()
+		errs = append(errs, err)
+		mu.Unlock()
+	}))
+
+	ran := make(chan bool, 1)
+	expect(t, p.Submit(func() { panic("boom") }), nil)
+	expect(t, p.Submit(func() { ran <- true }), nil)
+	expect(t, <-ran, true)
+
+	// A job submitting to the full pool is released by Close.
+	release := make(chan struct{})
+	submitted := make(chan error, 1)
+	expect(t, p.Submit(func() {
+		<-release
+		submitted <- p.Submit(func() {})
+	}), nil)
+	expect(t, p.Submit(func() {}), nil)
+	closed := make(chan struct{})
+	go func() {
+		p.Close()
+		close(closed)
+	}()
+	close(release)
+	<-closed
+	expect(t, <-submitted, ErrClosed)
+
+	mu.Lock()
+	defer mu.Unlock()
+	expect(t, len(errs), 1)
+	expect(t, errors.Is(errs[0], ErrPanicked), true)
+}
@@ -0,0 +1,132 @@
+// Package injecttask runs background tasks of queues such as asynq or
+// machinery with handler functions whose arguments are injected.
+//
+// Queue workers are adapted by implementing Task for their task type and
+// calling Mux.Process from their handler, e.g. for github.com/hibiken/asynq:
+//
+//	m := injecttask.New(inj, injecttask.WithRetry(func(ctx context.Context, _ injecttask.Task) injecttask.Retry {
+//		n, _ := asynq.GetRetryCount(ctx)
+//		max, _ := asynq.GetMaxRetry(ctx)
+//		return injecttask.Retry{Count: n, Max: max}
+//	}))
+//	srv.Run(asynq.HandlerFunc(func(ctx context.Context, t *asynq.Task) error {
+//		return m.Process(ctx, t)
+//	}))
+package injecttask
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/juanjiTech/inject/v2"
+)
+
+// ErrNoHandler is returned by Process for tasks of a type without handler.
+var ErrNoHandler = errors.New("no handler for task type")
+
+// Task represents a task taken from a queue.
+type Task interface {
+	// Type returns the type name the task was enqueued with.
+	Type() string
+	// Payload returns the task payload.
+	Payload() []byte
+}
+
+// Payload is the payload of the task being processed.
+type Payload []byte
+
+// Retry describes how often a task has been retried. It is mapped for every
+// execution.
+type Retry struct {
+	// Count is the number of times the task has been retried, 0 for the first
+	// execution.
+	Count int
+	// Max is the maximum number of retries of the task, 0 if unknown.
+	Max int
+}
+
+// Last reports whether this is the last execution of the task, after which a
+// failure is final.
+func (r Retry) Last() bool {
+	return r.Max > 0 && r.Count >= r.Max
+}
+
+// Option configures a Mux created by New.
+type Option func(*Mux)
+
+// WithRetry sets the function providing the Retry of a task, usually read from
+// ctx by the queue library. By default the Retry is zero.
+func WithRetry(fn func(ctx context.Context, task Task) Retry) Option {
+	return func(m *Mux) {
+		m.retry = fn
+	}
+}
+
+// Mux routes tasks to injected handler functions by task type.
+type Mux struct {
+	inj      inject.Injector
+	retry    func(ctx context.Context, task Task) Retry
+	mu       sync.RWMutex
+	handlers map[string]interface{}
+}
+
+// New returns a Mux resolving handler arguments from inj.
+func New(inj inject.Injector, opts ...Option) *Mux {
+	m := &Mux{
+		inj:      inj,
+		handlers: make(map[string]interface{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Handle registers fn as the handler for tasks of taskType, replacing any
+// previous handler. It returns an error if fn is not a function.
+func (m *Mux) Handle(taskType string, fn interface{}) error {
+	if t := reflect.TypeOf(fn); t == nil || t.Kind() != reflect.Func {
+		return fmt.Errorf("injecttask: handler for %q is %T, not a function", taskType, fn)
+	}
+	m.mu.Lock()
+	m.handlers[taskType] = fn
+	m.mu.Unlock()
+	return nil
+}
+
+// Process invokes the handler of the task's type in a new child scope of the
+// Injector, in which task is mapped both as Task and as its concrete type,
+// together with its Payload, its Retry and ctx as context.Context. The scope
+// is ended once the handler returns. It returns the injection error or the
+// non-nil error returned as the last result of the handler, which queues
+// usually take as the signal to retry the task.
+func (m *Mux) Process(ctx context.Context, task Task) error {
+	m.mu.RLock()
+	fn, ok := m.handlers[task.Type()]
+	m.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNoHandler, task.Type())
+	}
+
+	var retry Retry
+	if m.retry != nil {
+		retry = m.retry(ctx, task)
+	}
+	scope := m.inj.Child()
+	defer scope.End()
+	scope.Map(task, Payload(task.Payload()), retry).
+		MapTo(task, (*Task)(nil)).
+		MapTo(ctx, (*context.Context)(nil))
+
+	out, err := scope.Invoke(fn)
+	if err != nil {
+		return err
+	}
+	if len(out) > 0 {
+		err, _ = out[len(out)-1].Interface().(error)
+	}
+	return err
+}
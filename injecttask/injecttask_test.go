@@ -0,0 +1,82 @@
+package injecttask
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/juanjiTech/inject/v2"
+)
+
+/* Test Helpers */
+func expect(t testing.TB, actual interface{}, expect interface{}) {
+	t.Helper()
+	if actual != expect {
+		t.Errorf("Expected %v (type %v) - Got %v (type %v)", expect, reflect.TypeOf(expect), actual, reflect.TypeOf(actual))
+	}
+}
+
+type testTask struct {
+	typ     string
+	payload string
+}
+
+func (t *testTask) Type() string    { return t.typ }
+func (t *testTask) Payload() []byte { return []byte(t.payload) }
+
+type mailer struct {
+	sent []string
+}
+
+type retryKey struct{}
+
+func TestMux_Process(t *testing.T) {
+	m := &mailer{}
+	inj := inject.New()
+	inj.Map(m)
+
+	mux := New(inj, WithRetry(func(ctx context.Context, task Task) Retry {
+		n, _ := ctx.Value(retryKey{}).(int)
+		return Retry{Count: n, Max: 3}
+	}))
+	expect(t, mux.Handle("email:send", func(p Payload, m *mailer, task Task, raw *testTask, retry Retry, ctx context.Context) error {
+		if retry.Count < 2 {
+			return errors.New("smtp unavailable")
+		}
+		m.sent = append(m.sent, string(p))
+		return nil
+	}), nil)
+	expect(t, mux.Handle("bad", "not a function") != nil, true)
+
+	task := &testTask{typ: "email:send", payload: "welcome"}
+	for i := 0; i < 2; i++ {
+		ctx := context.WithValue(context.Background(), retryKey{}, i)
+		expect(t, mux.Process(ctx, task).Error(), "smtp unavailable")
+	}
+	expect(t, mux.Process(context.WithValue(context.Background(), retryKey{}, 2), task), nil)
+	expect(t, len(m.sent), 1)
+	expect(t, m.sent[0], "welcome")
+
+	err := mux.Process(context.Background(), &testTask{typ: "unknown"})
+	expect(t, errors.Is(err, ErrNoHandler), true)
+}
+
+func TestMux_Process_Missing(t *testing.T) {
+	mux := New(inject.New())
+	expect(t, mux.Handle("t", func(*mailer) {}), nil)
+	err := mux.Process(context.Background(), &testTask{typ: "t"})
+	expect(t, errors.Is(err, inject.ErrValueNotFound), true)
+
+	expect(t, mux.Handle("t", func(r Retry) {
+		expect(t, r, Retry{})
+		expect(t, r.Last(), false)
+	}), nil)
+	expect(t, mux.Process(context.Background(), &testTask{typ: "t"}), nil)
+}
+
+func TestRetry_Last(t *testing.T) {
+	expect(t, Retry{Count: 2, Max: 3}.Last(), false)
+	expect(t, Retry{Count: 3, Max: 3}.Last(), true)
+	expect(t, Retry{}.Last(), false)
+}
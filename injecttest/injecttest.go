@@ -0,0 +1,109 @@
+// Package injecttest runs table-driven tests whose bodies are invoked through
+// an Injector, so that every case resolves its dependencies from its own
+// child scope, with overrides declared by the case.
+package injecttest
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/juanjiTech/inject/v2"
+)
+
+// OverrideTag is the struct tag marking the fields of a case that are mapped
+// into its scope, e.g.
+//
+//	Clock inject.Clock `injecttest:"override"`
+//
+// Fields are bound as their declared type, which may be an interface the
+// value implements. Fields holding a nil pointer, interface, map, slice, func
+// or channel are not mapped, so that cases leave the default binding of the
+// parent in place by not setting them.
+const OverrideTag = "injecttest"
+
+// RunTable runs fn as a subtest for every case. Every subtest gets a new child
+// scope of inj, ended when the subtest finishes, in which the case is mapped
+// as C, the subtest as *testing.T, the scope itself as inject.Injector and the
+// fields of the case tagged `injecttest:"override"` as their field types,
+// overriding the bindings of inj. fn is invoked in that scope, so it can take
+// any of them or of the bindings of inj as arguments, e.g.
+//
+//	injecttest.RunTable(t, inj, cases, func(t *testing.T, tc testCase, svc *Service) {
+//		...
+//	})
+//
+// A subtest is named after the Name field of its case if C is a struct with
+// a string field of that name, and after its index otherwise. A subtest fails
+// if fn cannot be invoked or returns a non-nil error as its last result.
+func RunTable[C any](t *testing.T, inj inject.Injector, cases []C, fn interface{}) {
+	t.Helper()
+	if err := checkBody(fn); err != nil {
+		t.Fatal(err)
+	}
+	for i, tc := range cases {
+		tc := tc
+		t.Run(caseName(i, tc), func(t *testing.T) {
+			t.Helper()
+			scope := inj.Child()
+			defer scope.End()
+			scope.Set(inject.Type[C](), reflect.ValueOf(&tc).Elem())
+			scope.Map(t)
+			inject.MapAs[inject.Injector](scope, scope)
+			mapOverrides(scope, reflect.ValueOf(tc))
+
+			out, err := scope.Invoke(fn)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n := len(out); n > 0 && out[n-1].Type() == errorType {
+				if err, _ := out[n-1].Interface().(error); err != nil {
+					t.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// checkBody returns an error if fn is not a function.
+func checkBody(fn interface{}) error {
+	if t := reflect.TypeOf(fn); t == nil || t.Kind() != reflect.Func {
+		return fmt.Errorf("injecttest: %T is not a function", fn)
+	}
+	return nil
+}
+
+// caseName returns the name of the subtest of the i-th case tc.
+func caseName(i int, tc interface{}) string {
+	v := reflect.ValueOf(tc)
+	if v.Kind() == reflect.Struct {
+		if f := v.FieldByName("Name"); f.IsValid() && f.Kind() == reflect.String && f.String() != "" {
+			return f.String()
+		}
+	}
+	return fmt.Sprintf("case_%d", i)
+}
+
+// mapOverrides maps the override fields of the case v into scope.
+func mapOverrides(scope inject.TypeMapper, v reflect.Value) {
+	if v.Kind() != reflect.Struct {
+		return
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" || f.Tag.Get(OverrideTag) != "override" {
+			continue
+		}
+		fv := v.Field(i)
+		switch fv.Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+			if fv.IsNil() {
+				continue
+			}
+		}
+		scope.Set(f.Type, fv)
+	}
+}
@@ -0,0 +1,78 @@
+package injecttest
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/juanjiTech/inject/v2"
+)
+
+func expect(t *testing.T, a interface{}, b interface{}) {
+	t.Helper()
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("Expected %v (type %v) - Got %v (type %v)", b, reflect.TypeOf(b), a, reflect.TypeOf(a))
+	}
+}
+
+type greeter interface {
+	Greet(name string) string
+}
+
+type english struct{}
+
+func (english) Greet(name string) string { return "Hello, " + name }
+
+type german struct{}
+
+func (german) Greet(name string) string { return "Hallo, " + name }
+
+type service struct{ prefix string }
+
+func TestRunTable(t *testing.T) {
+	inj := inject.New()
+	inject.MapAs[greeter](inj, english{})
+	inj.Map(&service{prefix: "app"})
+
+	type testCase struct {
+		Name     string
+		Greeter  greeter  `injecttest:"override"`
+		Service  *service `injecttest:"override"`
+		Input    string
+		Expected string
+	}
+	cases := []testCase{
+		{Name: "default", Input: "Jeremy", Expected: "app: Hello, Jeremy"},
+		{Name: "german", Greeter: german{}, Input: "Jeremy", Expected: "app: Hallo, Jeremy"},
+		{Name: "service", Service: &service{prefix: "test"}, Input: "Jeremy", Expected: "test: Hello, Jeremy"},
+		{Input: "unnamed", Expected: "app: Hello, unnamed"},
+	}
+
+	var names []string
+	RunTable(t, inj, cases, func(t *testing.T, tc testCase, g greeter, s *service) error {
+		names = append(names, t.Name())
+		expect(t, s.prefix+": "+g.Greet(tc.Input), tc.Expected)
+		return nil
+	})
+	expect(t, names, []string{"TestRunTable/default", "TestRunTable/german", "TestRunTable/service", "TestRunTable/case_3"})
+
+	// Overrides do not leak into the injector.
+	g, err := inject.LoadT[greeter](inj)
+	expect(t, err, nil)
+	expect(t, g, greeter(english{}))
+}
+
+func TestRunTable_ScopeEnded(t *testing.T) {
+	var ended []int
+	RunTable(t, inject.New(), []int{1, 2}, func(n int, scope inject.Injector) {
+		scope.OnScopeEnd(func() { ended = append(ended, n) })
+	})
+	expect(t, ended, []int{1, 2})
+}
+
+func TestCaseName(t *testing.T) {
+	expect(t, caseName(0, struct{ Name string }{"named"}), "named")
+	expect(t, caseName(1, struct{ Name int }{1}), "case_1")
+	expect(t, caseName(2, "plain"), "case_2")
+	expect(t, fmt.Sprint(checkBody("not a function")), "injecttest: string is not a function")
+}
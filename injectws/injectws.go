@@ -0,0 +1,193 @@
+// Package injectws calls functions with injected arguments for the events of
+// WebSocket connections.
+//
+// Connections of github.com/gorilla/websocket implement Conn as they are;
+// other libraries are adapted by implementing Conn for their connection type.
+package injectws
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync/atomic"
+	"time"
+
+	"github.com/juanjiTech/inject/v2"
+)
+
+var (
+	errorType  = reflect.TypeOf((*error)(nil)).Elem()
+	stringType = reflect.TypeOf("")
+	bytesType  = reflect.TypeOf([]byte(nil))
+)
+
+// Conn represents a WebSocket connection.
+type Conn interface {
+	// ReadMessage blocks until the next message arrives and returns its type
+	// and data. It returns an error once the connection is closed.
+	ReadMessage() (messageType int, p []byte, err error)
+	// WriteMessage sends a message of the given type.
+	WriteMessage(messageType int, data []byte) error
+	// Close closes the connection.
+	Close() error
+}
+
+// Info describes a connection. It is mapped into the connection scope.
+type Info struct {
+	// ID identifies the connection among those served by a Server.
+	ID uint64
+	// Request is the upgraded HTTP request, or nil if none was given to Serve.
+	Request *http.Request
+	// Opened is the time Serve was called.
+	Opened time.Time
+}
+
+// Message is a message received from a connection. It is mapped into the
+// scope of the OnMessage handler.
+type Message struct {
+	Type int
+	Data []byte
+}
+
+// Closed is mapped into the scope of the OnClose handler. Err is the error
+// that ended the connection: the read error of the Conn, or the injection or
+// handler error that stopped serving it.
+type Closed struct {
+	Err error
+}
+
+// Handlers are the functions invoked for the events of a connection. Each of
+// them is optional. A handler may return an error as its last result, which
+// stops serving the connection; an OnMessage handler may also return a string
+// or []byte, which is written back as a message of the received type.
+type Handlers struct {
+	OnOpen    interface{}
+	OnMessage interface{}
+	OnClose   interface{}
+}
+
+// Server serves WebSocket connections with injected handlers.
+type Server struct {
+	inj      inject.Injector
+	handlers Handlers
+	ids      uint64
+}
+
+// New returns a Server resolving handler arguments from inj. It returns an
+// error if a handler is not a function or has unsupported results.
+func New(inj inject.Injector, h Handlers) (*Server, error) {
+	for _, c := range []struct {
+		event string
+		fn    interface{}
+		reply bool
+	}{{"OnOpen", h.OnOpen, false}, {"OnMessage", h.OnMessage, true}, {"OnClose", h.OnClose, false}} {
+		if c.fn == nil {
+			continue
+		}
+		if err := checkHandler(c.fn, c.reply); err != nil {
+			return nil, fmt.Errorf("injectws: %s handler: %w", c.event, err)
+		}
+	}
+	return &Server{inj: inj, handlers: h}, nil
+}
+
+func checkHandler(fn interface{}, reply bool) error {
+	t := reflect.TypeOf(fn)
+	if t.Kind() != reflect.Func {
+		return fmt.Errorf("%T is not a function", fn)
+	}
+	for i := 0; i < t.NumOut(); i++ {
+		switch out := t.Out(i); {
+		case out == errorType:
+		case reply && (out == stringType || out == bytesType):
+		default:
+			return fmt.Errorf("unsupported result type %v", out)
+		}
+	}
+	return nil
+}
+
+// Serve serves conn until it is closed or a handler fails, and closes it. The
+// connection gets its own child scope of the Injector, in which conn is mapped
+// both as Conn and as its concrete type, together with the connection Info, r
+// if it is not nil, and a context.Context derived from ctx that is canceled
+// when the connection ends. Every message is handled in a child scope of the
+// connection scope with the Message mapped. OnClose is invoked last, with
+// Closed mapped, after which the connection scope is ended.
+//
+// Serve returns the error of a failed handler, or nil once the connection was
+// closed by its peer.
+func (s *Server) Serve(ctx context.Context, conn Conn, r *http.Request) error {
+	ctx, cancel := context.WithCancel(ctx)
+	scope := s.inj.Child()
+	defer scope.End()
+	scope.Map(conn, Info{ID: atomic.AddUint64(&s.ids, 1), Request: r, Opened: time.Now()}).
+		MapTo(conn, (*Conn)(nil)).
+		MapTo(ctx, (*context.Context)(nil))
+	if r != nil {
+		scope.Map(r)
+	}
+
+	err := call(scope, s.handlers.OnOpen, nil)
+	var readErr error
+	for err == nil {
+		var msg Message
+		msg.Type, msg.Data, readErr = conn.ReadMessage()
+		if readErr != nil {
+			break
+		}
+		err = s.handle(scope, conn, msg)
+	}
+	cancel()
+
+	closed := Closed{Err: err}
+	if err == nil {
+		closed.Err = readErr
+	}
+	scope.Map(closed)
+	if closeErr := call(scope, s.handlers.OnClose, nil); err == nil {
+		err = closeErr
+	}
+	_ = conn.Close()
+	return err
+}
+
+func (s *Server) handle(scope inject.Injector, conn Conn, msg Message) error {
+	if s.handlers.OnMessage == nil {
+		return nil
+	}
+	child := scope.Child()
+	defer child.End()
+	child.Map(msg)
+	return call(child, s.handlers.OnMessage, func(data []byte) error {
+		return conn.WriteMessage(msg.Type, data)
+	})
+}
+
+// call invokes fn in scope and returns its error. Replies are passed to reply.
+func call(scope inject.Injector, fn interface{}, reply func([]byte) error) error {
+	if fn == nil {
+		return nil
+	}
+	out, err := scope.Invoke(fn)
+	if err != nil {
+		return err
+	}
+	for _, v := range out {
+		switch v.Type() {
+		case errorType:
+			if !v.IsNil() {
+				return v.Interface().(error)
+			}
+		case stringType:
+			err = reply([]byte(v.String()))
+		case bytesType:
+			err = reply(v.Bytes())
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
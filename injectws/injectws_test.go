@@ -0,0 +1,115 @@
+package injectws
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/juanjiTech/inject/v2"
+)
+
+/* Test Helpers */
+func expect(t testing.TB, actual interface{}, expect interface{}) {
+	t.Helper()
+	if actual != expect {
+		t.Errorf("Expected %v (type %v) - Got %v (type %v)", expect, reflect.TypeOf(expect), actual, reflect.TypeOf(actual))
+	}
+}
+
+const textMessage = 1
+
+type testConn struct {
+	in     []string
+	out    []string
+	closed bool
+}
+
+func (c *testConn) ReadMessage() (int, []byte, error) {
+	if len(c.in) == 0 {
+		return 0, nil, io.EOF
+	}
+	msg := c.in[0]
+	c.in = c.in[1:]
+	return textMessage, []byte(msg), nil
+}
+
+func (c *testConn) WriteMessage(messageType int, data []byte) error {
+	c.out = append(c.out, string(data))
+	return nil
+}
+
+func (c *testConn) Close() error {
+	c.closed = true
+	return nil
+}
+
+type room struct {
+	events []string
+}
+
+func TestServer_Serve(t *testing.T) {
+	r := &room{}
+	inj := inject.New()
+	inj.Map(r)
+
+	var ctx context.Context
+	s, err := New(inj, Handlers{
+		OnOpen: func(r *room, info Info, c context.Context) {
+			ctx = c
+			r.events = append(r.events, "open "+info.Request.URL.Path)
+		},
+		OnMessage: func(msg Message, conn Conn, c *testConn) string {
+			return strings.ToUpper(string(msg.Data))
+		},
+		OnClose: func(r *room, closed Closed) {
+			expect(t, ctx.Err(), context.Canceled)
+			expect(t, closed.Err, io.EOF)
+			r.events = append(r.events, "close")
+		},
+	})
+	expect(t, err, nil)
+
+	conn := &testConn{in: []string{"hello", "world"}}
+	expect(t, s.Serve(context.Background(), conn, httptest.NewRequest("GET", "/chat", nil)), nil)
+	expect(t, strings.Join(conn.out, ","), "HELLO,WORLD")
+	expect(t, strings.Join(r.events, ","), "open /chat,close")
+	expect(t, conn.closed, true)
+}
+
+func TestServer_HandlerError(t *testing.T) {
+	boom := errors.New("boom")
+	var closed Closed
+	s, err := New(inject.New(), Handlers{
+		OnMessage: func(msg Message) error {
+			if string(msg.Data) == "bad" {
+				return boom
+			}
+			return nil
+		},
+		OnClose: func(c Closed) { closed = c },
+	})
+	expect(t, err, nil)
+
+	conn := &testConn{in: []string{"good", "bad", "never read"}}
+	expect(t, s.Serve(context.Background(), conn, nil), boom)
+	expect(t, closed.Err, boom)
+	expect(t, len(conn.in), 1)
+	expect(t, conn.closed, true)
+
+	s, _ = New(inject.New(), Handlers{OnOpen: func(int) {}})
+	err = s.Serve(context.Background(), &testConn{}, nil)
+	expect(t, errors.Is(err, inject.ErrValueNotFound), true)
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(inject.New(), Handlers{OnOpen: "not a function"})
+	expect(t, err != nil, true)
+	_, err = New(inject.New(), Handlers{OnOpen: func() string { return "" }})
+	expect(t, err != nil, true)
+	_, err = New(inject.New(), Handlers{OnMessage: func() ([]byte, error) { return nil, nil }})
+	expect(t, err, nil)
+}
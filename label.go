@@ -0,0 +1,46 @@
+package inject
+
+import "strings"
+
+// WithLabel labels the Injector, e.g. "app" or "request", to tell the levels
+// of a parent chain apart in errors, traces and reports. Unlike other
+// options, a label is not inherited by children.
+func WithLabel(label string) Option {
+	return func(o *options) {
+		o.label = label
+	}
+}
+
+// labelOf returns the label set by opts.
+func labelOf(opts []Option) string {
+	if len(opts) == 0 {
+		return ""
+	}
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o.label
+}
+
+func (inj *injector) Label() string {
+	return inj.label
+}
+
+// labelChain renders the labels of chain such as "request → app → framework",
+// or returns "" if none of the injectors is labeled.
+func labelChain(chain []Injector) string {
+	labels := make([]string, len(chain))
+	labeled := false
+	for i, inj := range chain {
+		if labels[i] = inj.Label(); labels[i] != "" {
+			labeled = true
+		} else {
+			labels[i] = "unlabeled"
+		}
+	}
+	if !labeled {
+		return ""
+	}
+	return strings.Join(labels, " → ")
+}
@@ -0,0 +1,44 @@
+package inject
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWithLabel(t *testing.T) {
+	framework := New(WithLabel("framework"))
+	app := framework.Child(WithLabel("app"))
+	request := app.Child(WithLabel("request"))
+	expect(t, request.Label(), "request")
+	expect(t, app.Child().Label(), "")
+
+	_, err := request.Invoke(func(int) {})
+	expect(t, errors.Is(err, ErrValueNotFound), true)
+	expect(t, strings.HasSuffix(err.Error(), " in [request → app → framework]"), true)
+
+	_, err = app.Child().Invoke(func(int) {})
+	expect(t, strings.HasSuffix(err.Error(), " in [unlabeled → app → framework]"), true)
+
+	_, err = New().Invoke(func(int) {})
+	expect(t, strings.Contains(err.Error(), " in ["), false)
+}
+
+func TestWithLabel_TraceAndReport(t *testing.T) {
+	var buf bytes.Buffer
+	app := New(WithLabel("app"), WithTrace(&buf))
+	app.Map("a dep")
+	request := app.Child(WithLabel("request"))
+
+	_, err := request.Invoke(func(string) {})
+	expect(t, err, nil)
+	got := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	expect(t, got[1], "inject: [0 request] string: parent hop")
+	expect(t, got[2], "inject: [1 app] string: exact hit")
+
+	buf.Reset()
+	expect(t, request.WriteReport(&buf), nil)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	expect(t, strings.Fields(lines[1])[0], "1(app)")
+}
@@ -0,0 +1,61 @@
+package inject
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ScopeLeak describes a child scope that has not been ended in time, see
+// WithLeakDetection.
+type ScopeLeak struct {
+	// Created is when the scope was created by Child.
+	Created time.Time
+	// Stack is the stack trace of the Child call that created the scope.
+	Stack string
+}
+
+type leakDetector struct {
+	after  time.Duration
+	report func(ScopeLeak)
+}
+
+// WithLeakDetection makes every child scope created by Child, and the
+// children of those, report itself to report if End has not been called on it
+// within after of its creation. It records the stack of every Child call and
+// is meant for debugging scopes that are never ended, not for production.
+func WithLeakDetection(after time.Duration, report func(ScopeLeak)) Option {
+	return func(o *options) {
+		o.leaks = &leakDetector{after: after, report: report}
+	}
+}
+
+// watch starts the leak timer of the scope inj, created by the caller of the
+// caller of watch.
+func (inj *injector) watch(d *leakDetector) {
+	pcs := make([]uintptr, 32)
+	pcs = pcs[:runtime.Callers(3, pcs)]
+	leak := ScopeLeak{Created: time.Now()}
+	inj.extend().leak = time.AfterFunc(d.after, func() {
+		leak.Stack = formatStack(pcs)
+		d.report(leak)
+	})
+}
+
+func formatStack(pcs []uintptr) string {
+	var b strings.Builder
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		b.WriteString(frame.Function)
+		b.WriteString("\n\t")
+		b.WriteString(frame.File)
+		b.WriteString(":")
+		b.WriteString(strconv.Itoa(frame.Line))
+		b.WriteString("\n")
+		if !more {
+			return b.String()
+		}
+	}
+}
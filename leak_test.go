@@ -0,0 +1,30 @@
+package inject
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithLeakDetection(t *testing.T) {
+	leaks := make(chan ScopeLeak, 2)
+	inj := New(WithLeakDetection(10*time.Millisecond, func(l ScopeLeak) { leaks <- l }))
+
+	ended := inj.Child()
+	ended.End()
+	leaked := inj.Child()
+
+	select {
+	case l := <-leaks:
+		expect(t, strings.Contains(l.Stack, "TestWithLeakDetection"), true)
+		expect(t, l.Created.IsZero(), false)
+	case <-time.After(time.Second):
+		t.Fatal("leaked scope not reported")
+	}
+	select {
+	case <-leaks:
+		t.Fatal("ended scope reported")
+	case <-time.After(30 * time.Millisecond):
+	}
+	leaked.End()
+}
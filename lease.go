@@ -0,0 +1,68 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Pool is a pool of reusable values such as a *sync.Pool, from which MapPool
+// leases values to scopes.
+type Pool interface {
+	Get() interface{}
+	Put(interface{})
+}
+
+// lease is the pool of a binding made by MapPool.
+type lease struct {
+	pool Pool
+}
+
+// leasable reports whether the binding leases a value on resolution, as
+// opposed to a value that has been leased to a scope.
+func (b binding) leasable() bool {
+	return b.lease != nil && !b.value.IsValid()
+}
+
+func (inj *injector) MapPool(typ reflect.Type, pool Pool) TypeMapper {
+	if !inj.mutable() || !inj.mustBindable(typ) {
+		return inj
+	}
+	site := inj.callSite(1)
+	inj.mu.Lock()
+	inj.audit("MapPool", typ, site)
+	inj.store(typ, binding{method: "MapPool", site: site, lease: &lease{pool: pool}})
+	inj.mu.Unlock()
+	inj.notify()
+	return inj
+}
+
+// acquire returns the value of t leased to the scope inj from l, leasing one
+// the first time. The value is bound in inj until End puts it back.
+func (inj *injector) acquire(t reflect.Type, l *lease) (reflect.Value, error) {
+	inj.mu.Lock()
+	defer inj.mu.Unlock()
+	prev := inj.values[t]
+	if prev.lease == l && prev.value.IsValid() {
+		return prev.value, nil
+	}
+
+	v := reflect.ValueOf(l.pool.Get())
+	if !v.IsValid() || !v.Type().AssignableTo(t) {
+		return reflect.Value{}, fmt.Errorf("%w: pool of %v returned %v", ErrValueNotFound, t, v)
+	}
+	inj.store(t, binding{value: v, method: "MapPool", site: prev.site, lease: l})
+	ext := inj.extend()
+	ext.onEnd = append(ext.onEnd, func() {
+		inj.mu.Lock()
+		if cur := inj.values[t]; cur.lease == l {
+			if prev.bound() {
+				inj.store(t, prev)
+			} else {
+				inj.remove(t)
+			}
+		}
+		inj.mu.Unlock()
+		l.pool.Put(v.Interface())
+	})
+	return v, nil
+}
@@ -0,0 +1,68 @@
+package inject
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type countingPool struct {
+	sync.Pool
+	gets, puts int
+}
+
+func (p *countingPool) Get() interface{} {
+	p.gets++
+	return p.Pool.Get()
+}
+
+func (p *countingPool) Put(x interface{}) {
+	p.puts++
+	p.Pool.Put(x)
+}
+
+func TestInjector_MapPool(t *testing.T) {
+	pool := &countingPool{Pool: sync.Pool{New: func() interface{} { return new(bytes.Buffer) }}}
+	inj := New()
+	inj.MapPool(Type[*bytes.Buffer](), pool)
+
+	scope := inj.Child()
+	var first *bytes.Buffer
+	_, err := scope.Invoke(func(a, b *bytes.Buffer) {
+		expect(t, a, b)
+		first = a
+	})
+	expect(t, err, nil)
+	_, err = scope.Invoke(func(buf *bytes.Buffer) { expect(t, buf, first) })
+	expect(t, err, nil)
+	expect(t, pool.gets, 1)
+	expect(t, pool.puts, 0)
+
+	var report bytes.Buffer
+	expect(t, scope.WriteReport(&report), nil)
+	expect(t, strings.Contains(report.String(), "leased"), true)
+	expect(t, strings.Contains(report.String(), "pooled"), true)
+
+	scope.End()
+	expect(t, pool.puts, 1)
+	expect(t, scope.Value(Type[*bytes.Buffer]()).IsValid(), true)
+	expect(t, pool.gets, 2)
+	scope.End()
+	expect(t, pool.puts, 2)
+
+	// Leasing in the injector holding the pool keeps the pool bound.
+	_, err = inj.Invoke(func(*bytes.Buffer) {})
+	expect(t, err, nil)
+	inj.End()
+	expect(t, pool.puts, 3)
+	_, err = inj.Invoke(func(*bytes.Buffer) {})
+	expect(t, err, nil)
+	expect(t, pool.gets, 4)
+
+	empty := New()
+	empty.MapPool(Type[*testRepo](), &sync.Pool{})
+	_, err = empty.Invoke(func(*testRepo) {})
+	expect(t, errors.Is(err, ErrValueNotFound), true)
+}
@@ -0,0 +1,22 @@
+//go:build inject_light
+
+package inject
+
+// lightMode is set by the inject_light build tag, which restricts the package
+// to the reflect features available on TinyGo and WebAssembly targets:
+//
+//   - Interfaces are not resolved by scanning the bindings for a type
+//     implementing them, so they must be bound explicitly, e.g. with MapAs or
+//     MapTo.
+//   - Curry, InvokeMethod, the setter methods of WithApplyMethods and the
+//     method fallback of ApplyFuncs, which require reflect.MakeFunc or method
+//     lookups, fail with ErrUnsupported.
+//
+// It also leaves out the features needing larger packages: Manifest,
+// VerifyManifest, Recorder.WriteTo, ReadResolutions and GraphDoc.WriteHTML
+// fail with ErrUnsupported, and WithPprofLabels does not label invocations. As
+// goroutines are not told apart, a constructor resolving its own type through
+// the injector rather than its arguments fails with ErrCycle only once it has
+// waited for itself for 10 seconds, and so does any resolution waiting that
+// long for a construction in progress.
+const lightMode = true
@@ -0,0 +1,6 @@
+//go:build !inject_light
+
+package inject
+
+// lightMode is false in the default build, see light.go.
+const lightMode = false
@@ -0,0 +1,76 @@
+//go:build inject_light
+
+package inject
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+type lightSetter struct {
+	dep string
+}
+
+func (s *lightSetter) SetDep(dep string) { s.dep = dep }
+
+type lightFuncs struct {
+	String func() string
+}
+
+func TestLightMode(t *testing.T) {
+	inj := New()
+	inj.Map(&greeter{"Jeremy"})
+
+	_, err := LoadT[fmt.Stringer](inj)
+	expect(t, errors.Is(err, ErrValueNotFound), true)
+	MapAs[fmt.Stringer](inj, &greeter{"Jeremy"})
+	s, err := LoadT[fmt.Stringer](inj)
+	expect(t, err, nil)
+	expect(t, s.String(), "Hello, My name isJeremy")
+
+	_, err = inj.Curry(func(s fmt.Stringer, n int) {})
+	expect(t, errors.Is(err, ErrUnsupported), true)
+	_, err = inj.InvokeMethod(&lightSetter{}, "SetDep")
+	expect(t, errors.Is(err, ErrUnsupported), true)
+
+	inj.Map("a dep")
+	err = inj.Child(WithApplyMethods()).Apply(&lightSetter{})
+	expect(t, errors.Is(err, ErrUnsupported), true)
+
+	var funcs lightFuncs
+	err = New().ApplyFuncs(&funcs)
+	expect(t, errors.Is(err, ErrUnsupported), true)
+}
+
+func TestLightMode_SelfResolution(t *testing.T) {
+	defer func(d time.Duration) { selfWait = d }(selfWait)
+	selfWait = 20 * time.Millisecond
+
+	self := New()
+	expect(t, self.Provide(func() *testRepo {
+		_, err := self.Invoke(func(*testRepo) {})
+		expect(t, errors.Is(err, ErrCycle), true)
+		return &testRepo{dsn: "self"}
+	}), nil)
+	expect(t, self.Value(Type[*testRepo]()).Interface().(*testRepo).dsn, "self")
+
+	// Resolutions waiting for a construction shorter than selfWait succeed.
+	shared := New()
+	expect(t, shared.Provide(func() *testRepo {
+		time.Sleep(5 * time.Millisecond)
+		return &testRepo{}
+	}), nil)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := shared.Invoke(func(*testRepo) {})
+			expect(t, err, nil)
+		}()
+	}
+	wg.Wait()
+}
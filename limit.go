@@ -0,0 +1,56 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// WithInvokeLimit returns an Option limiting the Injector and its children to
+// n concurrent invocations of each of fns, e.g. for a handler fronting a
+// resource that must not be called with unbounded parallelism. Invocations
+// beyond the limit wait for a running one to return; with InvokeContext they
+// give up once the context is done. The limit is shared by the whole injector
+// tree, and by all closures created from the same function literal, as
+// functions are told apart by their code. It panics if n is less than 1.
+func WithInvokeLimit(n int, fns ...interface{}) Option {
+	if n < 1 {
+		panic(fmt.Sprintf("inject: WithInvokeLimit of %d concurrent invocations", n))
+	}
+	sems := make(map[uintptr]chan struct{}, len(fns))
+	for _, f := range fns {
+		if err := checkFunc(f); err != nil {
+			panic(fmt.Errorf("inject: WithInvokeLimit: %w", err))
+		}
+		sems[reflect.ValueOf(f).Pointer()] = make(chan struct{}, n)
+	}
+	return func(o *options) {
+		limits := make(map[uintptr]chan struct{}, len(o.limits)+len(sems))
+		for pc, sem := range o.limits {
+			limits[pc] = sem
+		}
+		for pc, sem := range sems {
+			limits[pc] = sem
+		}
+		o.limits = limits
+	}
+}
+
+// acquireLimit waits for an invocation slot of f if its invocations are
+// limited, and returns the function releasing it.
+func (inj *injector) acquireLimit(f interface{}) (func(), error) {
+	sem := inj.opts.limits[reflect.ValueOf(f).Pointer()]
+	if sem == nil {
+		return func() {}, nil
+	}
+	release := func() { <-sem }
+	if inj.ctx == nil {
+		sem <- struct{}{}
+		return release, nil
+	}
+	select {
+	case sem <- struct{}{}:
+		return release, nil
+	case <-inj.ctx.Done():
+		return nil, fmt.Errorf("inject: waiting to invoke %s: %w", targetName(f), inj.ctx.Err())
+	}
+}
@@ -0,0 +1,76 @@
+package inject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithInvokeLimit(t *testing.T) {
+	var running, peak int32
+	handler := func(s string) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+	}
+	inj := New(WithInvokeLimit(2, handler))
+	inj.Map("a dep")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := inj.Child().Invoke(handler)
+			expect(t, err, nil)
+		}()
+	}
+	wg.Wait()
+	expect(t, atomic.LoadInt32(&peak), int32(2))
+
+	// Other functions are not limited.
+	_, err := inj.Invoke(func(s string) {})
+	expect(t, err, nil)
+}
+
+func TestWithInvokeLimit_Context(t *testing.T) {
+	var calls int32
+	holding, block := make(chan struct{}), make(chan struct{})
+	handler := func() {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			close(holding)
+			<-block
+		}
+	}
+	inj := New(WithInvokeLimit(1, handler))
+
+	go func() { _, _ = inj.Invoke(handler) }()
+	<-holding
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	_, err := inj.InvokeContext(ctx, handler)
+	expect(t, errors.Is(err, context.DeadlineExceeded), true)
+	expect(t, atomic.LoadInt32(&calls), int32(1))
+	close(block)
+}
+
+func TestWithInvokeLimit_Invalid(t *testing.T) {
+	for _, n := range []int{0, -1} {
+		func() {
+			defer func() {
+				expect(t, fmt.Sprint(recover()), fmt.Sprintf("inject: WithInvokeLimit of %d concurrent invocations", n))
+			}()
+			WithInvokeLimit(n, func() {})
+		}()
+	}
+}
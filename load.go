@@ -0,0 +1,245 @@
+package inject
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// loadCollection builds a value of the slice, array or map type t for Load
+// when t is not bound itself. Slices and arrays hold the values of every group
+// that are assignable to their element type, the values of parents first and
+// groups in order of name. Maps keyed by reflect.Type or a string type hold
+// every bound value assignable to their element type, keyed by its bound type
+// or the qualified name of it; the nearest binding of a type wins. It returns
+// an invalid value if t is of another kind or nothing was found.
+func (inj *injector) loadCollection(t reflect.Type) (reflect.Value, error) {
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		values := inj.groupValues(t.Elem())
+		if len(values) == 0 {
+			return reflect.Value{}, nil
+		}
+		if t.Kind() == reflect.Slice {
+			return reflect.Append(reflect.MakeSlice(t, 0, len(values)), values...), nil
+		}
+		if len(values) != t.Len() {
+			return reflect.Value{}, fmt.Errorf("%w: %v from %d group values", ErrValueCanNotSet, t, len(values))
+		}
+		out := reflect.New(t).Elem()
+		for i, v := range values {
+			out.Index(i).Set(v)
+		}
+		return out, nil
+	case reflect.Map:
+		if t.Key() != typeType && t.Key().Kind() != reflect.String {
+			return reflect.Value{}, nil
+		}
+		return inj.loadMap(t)
+	}
+	return reflect.Value{}, nil
+}
+
+var typeType = reflect.TypeOf((*reflect.Type)(nil)).Elem()
+
+// groupValues returns the values of every group in the injector and its
+// parents that are assignable to elem.
+func (inj *injector) groupValues(elem reflect.Type) []reflect.Value {
+	var chain []*injector
+	for cur := Injector(inj); cur != nil; {
+		in, ok := cur.(*injector)
+		if !ok {
+			break
+		}
+		chain = append(chain, in)
+		cur = in.loadParent()
+	}
+
+	var out []reflect.Value
+	for i := len(chain) - 1; i >= 0; i-- {
+		chain[i].mu.RLock()
+		var groups map[string][]reflect.Value
+		if ext := chain[i].extension(); ext != nil {
+			groups = ext.groups
+		}
+		names := make([]string, 0, len(groups))
+		for name := range groups {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			for _, val := range groups[name] {
+				if val.IsValid() && val.Type().AssignableTo(elem) {
+					out = append(out, val)
+				}
+			}
+		}
+		chain[i].mu.RUnlock()
+	}
+	return out
+}
+
+func (inj *injector) loadMap(t reflect.Type) (reflect.Value, error) {
+	out := reflect.MakeMap(t)
+	seen := make(map[reflect.Type]bool)
+	for _, e := range chainEntries(inj) {
+		if seen[e.typ] || !e.binding.bound() || !e.typ.AssignableTo(t.Elem()) {
+			continue
+		}
+		seen[e.typ] = true
+		v, err := inj.resolve(e.typ)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if !v.IsValid() {
+			continue
+		}
+		key := reflect.ValueOf(e.typ)
+		if t.Key() != typeType {
+			key = reflect.ValueOf(qualifiedName(e.typ)).Convert(t.Key())
+		}
+		out.SetMapIndex(key, v)
+	}
+	if out.Len() == 0 {
+		return reflect.Value{}, nil
+	}
+	return out, nil
+}
+
+// loadConverted resolves a bound value whose type is compatible with t and
+// converts it to t for Load, when t is not bound itself. Numbers convert
+// between integer and floating-point kinds, strings and byte slices into each
+// other and other strings, and interfaces into interfaces implemented by the
+// dynamic value. Candidates are taken from the nearest level of the parent
+// chain that has any, preferring integers for integers and floating-point
+// numbers for floating-point numbers. Several candidates left are ambiguous.
+// It returns an invalid value if there is no candidate, and an error if the
+// conversion would lose information or the dynamic value does not implement
+// t.
+func (inj *injector) loadConverted(t reflect.Type) (reflect.Value, error) {
+	var candidates, others []reflect.Type
+	level := -1
+	for _, e := range chainEntries(inj) {
+		if level >= 0 && e.level != level {
+			break
+		}
+		if e.typ == t || !e.binding.bound() || !convertible(e.typ, t) {
+			continue
+		}
+		level = e.level
+		if isFloat(e.typ.Kind()) == isFloat(t.Kind()) {
+			candidates = append(candidates, e.typ)
+		} else {
+			others = append(others, e.typ)
+		}
+	}
+	if len(candidates) == 0 {
+		candidates = others
+	}
+	switch len(candidates) {
+	case 0:
+		return reflect.Value{}, nil
+	case 1:
+	default:
+		names := make([]string, len(candidates))
+		for i, c := range candidates {
+			names[i] = c.String()
+		}
+		return reflect.Value{}, fmt.Errorf("%w: %v can be loaded from %s", ErrAmbiguous, t, strings.Join(names, ", "))
+	}
+	v, err := inj.resolveFor(candidates[0], "Load")
+	if err != nil || !v.IsValid() {
+		return reflect.Value{}, err
+	}
+	return convertValue(v, candidates[0], t)
+}
+
+// convertible reports whether Load converts values of type from to type to.
+func convertible(from, to reflect.Type) bool {
+	switch {
+	case isNumber(from.Kind()) && isNumber(to.Kind()):
+		return true
+	case isText(from) && isText(to):
+		return true
+	case from.Kind() == reflect.Interface && to.Kind() == reflect.Interface:
+		return true
+	}
+	return false
+}
+
+func isNumber(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Float64
+}
+
+func isFloat(k reflect.Kind) bool {
+	return k == reflect.Float32 || k == reflect.Float64
+}
+
+// isText reports whether t is a string or byte slice type.
+func isText(t reflect.Type) bool {
+	return t.Kind() == reflect.String || t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8
+}
+
+// convertValue converts v, the value bound as from, to t, which must be
+// convertible from it.
+func convertValue(v reflect.Value, from, t reflect.Type) (reflect.Value, error) {
+	if t.Kind() == reflect.Interface {
+		if v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return reflect.Zero(t), nil
+			}
+			v = v.Elem()
+		}
+		if !v.Type().Implements(t) {
+			return reflect.Value{}, fmt.Errorf("%w: %v holds %v, which does not implement %v",
+				ErrValueCanNotSet, from, v.Type(), t)
+		}
+		return v.Convert(t), nil
+	}
+	if t.Kind() == reflect.Slice && v.Kind() == reflect.Slice {
+		// A named byte slice type is not convertible to another directly.
+		v = v.Convert(reflect.TypeOf([]byte(nil)))
+	}
+	out := v.Convert(t)
+	if isNumber(v.Kind()) && !sameNumber(v, out) {
+		return reflect.Value{}, fmt.Errorf("%w: %v(%v) does not fit into %v", ErrValueCanNotSet, v.Type(), v, t)
+	}
+	return out, nil
+}
+
+// sameNumber reports whether the numbers a and b are equal, or both NaN.
+func sameNumber(a, b reflect.Value) bool {
+	if isFloat(a.Kind()) && isFloat(b.Kind()) {
+		x, y := a.Float(), b.Float()
+		return x == y || x != x && y != y
+	}
+	if isFloat(b.Kind()) {
+		a, b = b, a
+	}
+	neg, mag := integer(b)
+	if !isFloat(a.Kind()) {
+		negA, magA := integer(a)
+		return neg == negA && mag == magA
+	}
+	// Integers have at most 64 bits, so floating-point numbers equal to one
+	// are integral and below 2^64 in magnitude, and convert exactly.
+	f := a.Float()
+	if neg {
+		f = -f
+	}
+	return f == math.Trunc(f) && f >= 0 && f < 1<<64 && uint64(f) == mag
+}
+
+// integer returns the sign and magnitude of the integer v.
+func integer(v reflect.Value) (neg bool, mag uint64) {
+	if v.Kind() >= reflect.Uint && v.Kind() <= reflect.Uintptr {
+		return false, v.Uint()
+	}
+	i := v.Int()
+	if i < 0 {
+		return true, uint64(-(i + 1)) + 1
+	}
+	return false, uint64(i)
+}
@@ -0,0 +1,114 @@
+package inject
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type route string
+
+func TestInjector_Load_Slice(t *testing.T) {
+	inj := New()
+	inj.MapGroup("routes", route("auth"), route("log"))
+	child := inj.Child()
+	child.MapGroup("admin", route("admin"), "not a route")
+
+	var all []route
+	expect(t, child.Load(&all), nil)
+	expect(t, fmt.Sprint(all), "[auth log admin]")
+
+	var arr [3]route
+	expect(t, child.Load(&arr), nil)
+	expect(t, arr[2], route("admin"))
+	var short [2]route
+	expect(t, errors.Is(child.Load(&short), ErrValueCanNotSet), true)
+
+	child.Map([]route{"bound"})
+	expect(t, child.Load(&all), nil)
+	expect(t, fmt.Sprint(all), "[bound]")
+
+	var none []int
+	expect(t, errors.Is(child.Load(&none), ErrValueNotFound), true)
+}
+
+func TestInjector_Load_Map(t *testing.T) {
+	inj := New()
+	inj.MapTo(&greeter{Name: "parent"}, (*fmt.Stringer)(nil))
+	inj.Map(&greeter{Name: "Jeremy"}, 1)
+	child := inj.Child()
+	child.MapTo(&greeter{Name: "child"}, (*fmt.Stringer)(nil))
+
+	var byType map[reflect.Type]fmt.Stringer
+	expect(t, child.Load(&byType), nil)
+	expect(t, len(byType), 2)
+	expect(t, byType[Type[fmt.Stringer]()].String(), "Hello, My name ischild")
+	expect(t, byType[Type[*greeter]()].String(), "Hello, My name isJeremy")
+
+	var byName map[string]fmt.Stringer
+	expect(t, child.Load(&byName), nil)
+	expect(t, byName["*github.com/juanjiTech/inject/v2.greeter"].String(), "Hello, My name isJeremy")
+
+	var unsupported map[int]fmt.Stringer
+	expect(t, errors.Is(child.Load(&unsupported), ErrValueNotFound), true)
+}
+
+type userID int64
+
+func TestInjector_Load_Convert(t *testing.T) {
+	inj := New()
+	inj.Map(42, "text", 0.5)
+
+	var n int64
+	expect(t, inj.Load(&n), nil)
+	expect(t, n, int64(42))
+	var id userID
+	expect(t, inj.Child().Load(&id), nil)
+	expect(t, id, userID(42))
+	var f float32
+	expect(t, inj.Load(&f), nil)
+	expect(t, f, float32(0.5))
+	var b []byte
+	expect(t, inj.Load(&b), nil)
+	expect(t, string(b), "text")
+	var s string
+	expect(t, inj.Load(&s), nil)
+	expect(t, s, "text")
+
+	ambiguous := New()
+	ambiguous.Map(-1, uint(2), 1.5)
+	var u uint8
+	err := ambiguous.Load(&u)
+	expect(t, errors.Is(err, ErrAmbiguous), true)
+	expect(t, err.Error(), "ambiguous binding: uint8 can be loaded from int, uint")
+
+	// Lossy conversions fail.
+	lossy := New()
+	lossy.Map(-1)
+	err = lossy.Load(&u)
+	expect(t, errors.Is(err, ErrValueCanNotSet), true)
+	expect(t, err.Error(), "value can not set: int(-1) does not fit into uint8")
+	lossy.Map(300)
+	child := lossy.Child()
+	child.Map(1.5)
+	err = child.Load(&u)
+	expect(t, err.Error(), "value can not set: float64(1.5) does not fit into uint8")
+	var i int
+	expect(t, child.Load(&i), nil)
+	expect(t, i, 300)
+}
+
+func TestInjector_Load_ConvertInterface(t *testing.T) {
+	inj := New()
+	inj.MapTo(&greeter{"Jeremy"}, (*interface{})(nil))
+
+	var s fmt.Stringer
+	expect(t, inj.Load(&s), nil)
+	expect(t, s.String(), "Hello, My name isJeremy")
+
+	var e error
+	err := inj.Load(&e)
+	expect(t, errors.Is(err, ErrValueCanNotSet), true)
+	expect(t, err.Error(), "value can not set: interface {} holds *inject.greeter, which does not implement error")
+}
@@ -0,0 +1,86 @@
+package inject
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// manifestVersion is the version of the format written by Manifest.
+const manifestVersion = 1
+
+type manifest struct {
+	Version  int             `json:"version"`
+	Bindings []manifestEntry `json:"bindings"`
+}
+
+// manifestEntry describes a binding of an injector at Level of the parent
+// chain. Origin and Meta are informational and not compared by
+// VerifyManifest, as Origin changes with every edit of the registering file.
+type manifestEntry struct {
+	Level    int               `json:"level"`
+	Scope    string            `json:"scope,omitempty"`
+	Type     string            `json:"type"`
+	Method   string            `json:"method"`
+	Lifetime string            `json:"lifetime,omitempty"`
+	Provider string            `json:"provider,omitempty"`
+	Origin   string            `json:"origin,omitempty"`
+	Meta     map[string]string `json:"meta,omitempty"`
+}
+
+func (e manifestEntry) key() string {
+	return fmt.Sprintf("%d %s", e.Level, e.Type)
+}
+
+// differs returns how e differs from want, or "" if it does not.
+func (e manifestEntry) differs(want manifestEntry) string {
+	var diffs []string
+	if e.Scope != want.Scope {
+		diffs = append(diffs, fmt.Sprintf("scope %q, want %q", e.Scope, want.Scope))
+	}
+	if e.Method != want.Method {
+		diffs = append(diffs, fmt.Sprintf("method %s, want %s", e.Method, want.Method))
+	}
+	if e.Lifetime != want.Lifetime {
+		diffs = append(diffs, fmt.Sprintf("lifetime %q, want %q", e.Lifetime, want.Lifetime))
+	}
+	if e.Provider != want.Provider {
+		diffs = append(diffs, fmt.Sprintf("provider %q, want %q", e.Provider, want.Provider))
+	}
+	return strings.Join(diffs, ", ")
+}
+
+func (inj *injector) manifest() manifest {
+	m := manifest{Version: manifestVersion, Bindings: []manifestEntry{}}
+	labels := map[int]string{}
+	level := 0
+	for cur := Injector(inj); cur != nil; level++ {
+		i, ok := cur.(*injector)
+		if !ok {
+			break
+		}
+		labels[level] = i.label
+		cur = i.loadParent()
+	}
+	for _, e := range chainEntries(inj) {
+		if !e.binding.bound() {
+			continue
+		}
+		entry := manifestEntry{
+			Level:  e.level,
+			Scope:  labels[e.level],
+			Type:   qualifiedName(e.typ),
+			Method: e.binding.method,
+		}
+		if p := e.binding.provider; p != nil {
+			entry.Lifetime = p.lifetime.String()
+			entry.Provider = p.name
+		}
+		entry.Meta = copyMeta(e.binding.meta)
+		if s := e.binding.site; s != nil {
+			entry.Origin = fmt.Sprintf("%s:%d", filepath.Base(s.file), s.line)
+		}
+		m.Bindings = append(m.Bindings, entry)
+	}
+	return m
+}
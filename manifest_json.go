@@ -0,0 +1,48 @@
+//go:build !inject_light
+
+package inject
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+func (inj *injector) Manifest() ([]byte, error) {
+	return json.MarshalIndent(inj.manifest(), "", "  ")
+}
+
+func (inj *injector) VerifyManifest(data []byte) error {
+	var want manifest
+	if err := json.Unmarshal(data, &want); err != nil {
+		return fmt.Errorf("inject: reading manifest: %w", err)
+	}
+	if want.Version != manifestVersion {
+		return fmt.Errorf("inject: unsupported manifest version %d", want.Version)
+	}
+	got := inj.manifest()
+	wanted := make(map[string]manifestEntry, len(want.Bindings))
+	for _, e := range want.Bindings {
+		wanted[e.key()] = e
+	}
+	var diffs []string
+	seen := make(map[string]bool, len(got.Bindings))
+	for _, e := range got.Bindings {
+		seen[e.key()] = true
+		w, ok := wanted[e.key()]
+		if !ok {
+			diffs = append(diffs, fmt.Sprintf("added %s at level %d", e.Type, e.Level))
+		} else if d := e.differs(w); d != "" {
+			diffs = append(diffs, fmt.Sprintf("changed %s at level %d: %s", e.Type, e.Level, d))
+		}
+	}
+	for _, e := range want.Bindings {
+		if !seen[e.key()] {
+			diffs = append(diffs, fmt.Sprintf("removed %s at level %d", e.Type, e.Level))
+		}
+	}
+	if len(diffs) > 0 {
+		return fmt.Errorf("%w: %s", ErrWiringChanged, strings.Join(diffs, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+//go:build inject_light
+
+package inject
+
+// Manifest fails with ErrUnsupported in the inject_light build, which does not
+// link encoding/json.
+func (inj *injector) Manifest() ([]byte, error) {
+	return nil, ErrUnsupported
+}
+
+// VerifyManifest fails with ErrUnsupported in the inject_light build, see
+// Manifest.
+func (inj *injector) VerifyManifest(data []byte) error {
+	return ErrUnsupported
+}
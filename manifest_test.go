@@ -0,0 +1,66 @@
+//go:build !inject_light
+
+package inject
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestManifest(t *testing.T) {
+	app := New(WithLabel("app"), WithCallSites())
+	app.Map("a dep")
+	expect(t, app.Provide(func() *testRepo { return &testRepo{} }, WithLifetime(Prototype)), nil)
+	req := app.Child(WithLabel("request"))
+	req.Map(&greeter{"Jeremy"})
+
+	data, err := req.Manifest()
+	expect(t, err, nil)
+	var m manifest
+	expect(t, json.Unmarshal(data, &m), nil)
+	expect(t, m.Version, 1)
+	expect(t, len(m.Bindings), 3)
+	expect(t, m.Bindings[0].Type, "*github.com/juanjiTech/inject/v2.greeter")
+	expect(t, m.Bindings[0].Scope, "request")
+	expect(t, m.Bindings[0].Level, 0)
+	expect(t, strings.HasPrefix(m.Bindings[0].Origin, "manifest_test.go:"), true)
+	expect(t, m.Bindings[1].Type, "*github.com/juanjiTech/inject/v2.testRepo")
+	expect(t, m.Bindings[1].Lifetime, "prototype")
+	expect(t, m.Bindings[1].Provider != "", true)
+	expect(t, m.Bindings[2].Type, "string")
+	expect(t, m.Bindings[2].Scope, "app")
+
+	again, err := req.Manifest()
+	expect(t, err, nil)
+	expect(t, string(again), string(data))
+	expect(t, req.VerifyManifest(data), nil)
+}
+
+func TestVerifyManifest(t *testing.T) {
+	inj := New()
+	inj.Map("a dep", 1)
+	data, err := inj.Manifest()
+	expect(t, err, nil)
+
+	inj.Map(&greeter{})
+	expect(t, inj.Provide(func() int { return 2 }), nil)
+	inj.Reset(KeepParent)
+	inj.Map("rebound", &greeter{})
+	expect(t, inj.Provide(func() int { return 2 }), nil)
+
+	err = inj.VerifyManifest(data)
+	expect(t, errors.Is(err, ErrWiringChanged), true)
+	msg := err.Error()
+	expect(t, strings.Contains(msg, "added *github.com/juanjiTech/inject/v2.greeter at level 0"), true)
+	expect(t, strings.Contains(msg, "changed int at level 0: method Provide, want Map"), true)
+	expect(t, strings.Contains(msg, "string"), false)
+
+	inj.Reset(KeepParent)
+	err = inj.VerifyManifest(data)
+	expect(t, strings.Contains(err.Error(), "removed int at level 0; removed string at level 0"), true)
+
+	expect(t, errors.Is(inj.VerifyManifest([]byte(`{"version":2}`)), ErrWiringChanged), false)
+	expect(t, inj.VerifyManifest([]byte(`not json`)) != nil, true)
+}
@@ -0,0 +1,39 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// MapNil binds the type I, usually an optional interface, to its nil value,
+// e.g. MapNil[Tracer](inj) when tracing is disabled. Unlike a type that is
+// not bound, I then resolves: functions taking I are invoked with nil, GetG
+// and LoadT succeed with nil, and OriginOf reports the binding with the
+// method "MapNil", so that optional dependencies can tell a deliberate "no
+// value" from a missing binding. I must be an interface, pointer, map,
+// slice, func or channel type; other types are misuse failing with
+// ErrValueCanNotSet.
+func MapNil[I any](m TypeMapper) TypeMapper {
+	t := Type[I]()
+	switch t.Kind() {
+	case reflect.Interface, reflect.Ptr, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+	default:
+		err := fmt.Errorf("%w: %v has no nil value", ErrValueCanNotSet, t)
+		if inj, ok := m.(*injector); ok {
+			inj.misuse(err)
+			return inj
+		}
+		panic(fmt.Errorf("inject: %w", err))
+	}
+	if inj, ok := m.(*injector); ok {
+		return inj.set(t, reflect.Zero(t), "MapNil", inj.callSite(1))
+	}
+	return m.Set(t, reflect.Zero(t))
+}
+
+// IsNilBinding reports whether t resolves from inj to a nil value bound with
+// MapNil, rather than to a value or not at all.
+func IsNilBinding(inj Injector, t reflect.Type) bool {
+	info, ok := inj.OriginOf(t)
+	return ok && info.Method == "MapNil" && info.Type == t
+}
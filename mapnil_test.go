@@ -0,0 +1,56 @@
+package inject
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type optionalTracer interface {
+	Trace(span string)
+}
+
+func TestMapNil(t *testing.T) {
+	inj := New()
+	tracerType := Type[optionalTracer]()
+	_, ok := GetG[optionalTracer](inj)
+	expect(t, ok, false)
+	expect(t, IsNilBinding(inj, tracerType), false)
+
+	MapNil[optionalTracer](inj)
+	tr, ok := GetG[optionalTracer](inj)
+	expect(t, ok, true)
+	expect(t, tr, nil)
+	expect(t, IsNilBinding(inj.Child(), tracerType), true)
+
+	called := false
+	_, err := inj.Invoke(func(tr optionalTracer) {
+		called = true
+		expect(t, tr == nil, true)
+	})
+	expect(t, err, nil)
+	expect(t, called, true)
+
+	s := struct {
+		Tracer optionalTracer `inject:""`
+	}{}
+	expect(t, inj.Apply(&s), nil)
+	expect(t, s.Tracer, nil)
+
+	MapNil[*greeter](inj)
+	g, err := LoadT[*greeter](inj)
+	expect(t, err, nil)
+	expect(t, g == nil, true)
+
+	inj.Map(&greeter{"Jeremy"})
+	expect(t, IsNilBinding(inj, Type[*greeter]()), false)
+}
+
+func TestMapNil_NotNilable(t *testing.T) {
+	inj := New(WithErrorsOnly())
+	MapNil[int](inj)
+	expect(t, errors.Is(inj.Err(), ErrValueCanNotSet), true)
+	expect(t, fmt.Sprint(inj.Err()), "value can not set: int has no nil value")
+	_, ok := GetG[int](inj)
+	expect(t, ok, false)
+}
@@ -0,0 +1,63 @@
+package inject
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// memoEntry holds the results of a function invoked by InvokeMemo, valid as
+// long as the generation of the injector is gen.
+type memoEntry struct {
+	gen uint64
+	// f is the function value the results are for. Keeping it also keeps its
+	// closure from being freed, so that its address is not reused.
+	f   interface{}
+	out []reflect.Value
+}
+
+// InvokeMemo calls f like Invoke and caches its results, keyed by the function
+// value f and the generation of the bindings in the parent chain. Later calls
+// with the same f return the cached results until a binding of the injector
+// or a parent changes. Closures are told apart by identity, so closures of the
+// same function literal capturing different variables get their own results,
+// and only the results of the last closure of a function literal are cached.
+// Every call returns its own slice of results. Results ending in a non-nil
+// error are not cached, and nothing is cached if a parent is not created by
+// this package.
+func (inj *injector) InvokeMemo(f interface{}) ([]reflect.Value, error) {
+	gen, cacheable := inj.generation()
+	if !cacheable || reflect.TypeOf(f).Kind() != reflect.Func {
+		return inj.Invoke(f)
+	}
+	key := reflect.ValueOf(f).Pointer()
+
+	inj.mu.RLock()
+	var e memoEntry
+	var ok bool
+	if ext := inj.extension(); ext != nil {
+		e, ok = ext.memo[key]
+	}
+	inj.mu.RUnlock()
+	if ok && e.gen == gen && closureOf(e.f) == closureOf(f) {
+		return append([]reflect.Value(nil), e.out...), nil
+	}
+
+	out, err := inj.Invoke(f)
+	if err != nil || methodError(out) != nil {
+		return out, err
+	}
+	inj.mu.Lock()
+	ext := inj.extend()
+	if ext.memo == nil {
+		ext.memo = make(map[uintptr]memoEntry)
+	}
+	ext.memo[key] = memoEntry{gen: gen, f: f, out: append([]reflect.Value(nil), out...)}
+	inj.mu.Unlock()
+	return out, nil
+}
+
+// closureOf returns the address of the closure of the function f, which tells
+// apart closures sharing the code that reflect.Value.Pointer returns.
+func closureOf(f interface{}) unsafe.Pointer {
+	return (*[2]unsafe.Pointer)(unsafe.Pointer(&f))[1]
+}
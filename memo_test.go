@@ -0,0 +1,55 @@
+package inject
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestInjector_InvokeMemo(t *testing.T) {
+	parent := New()
+	parent.Map("a")
+	inj := parent.Child()
+	calls := 0
+	derive := func(s string) string {
+		calls++
+		return s + "!"
+	}
+
+	for i := 0; i < 3; i++ {
+		out, err := inj.InvokeMemo(derive)
+		expect(t, err, nil)
+		expect(t, out[0].String(), "a!")
+	}
+	expect(t, calls, 1)
+
+	parent.Map("b")
+	out, err := inj.InvokeMemo(derive)
+	expect(t, err, nil)
+	expect(t, out[0].String(), "b!")
+	expect(t, calls, 2)
+
+	failures := 0
+	fail := func() error {
+		failures++
+		return errors.New("not cached")
+	}
+	_, _ = inj.InvokeMemo(fail)
+	_, _ = inj.InvokeMemo(fail)
+	expect(t, failures, 2)
+
+	_, err = inj.InvokeMemo(func(int) {})
+	expect(t, errors.Is(err, ErrValueNotFound), true)
+
+	suffixed := func(suffix string) func(string) string {
+		return func(s string) string { return s + suffix }
+	}
+	question, dots := suffixed("?"), suffixed("...")
+	out, _ = inj.InvokeMemo(question)
+	expect(t, out[0].String(), "b?")
+	out, _ = inj.InvokeMemo(dots)
+	expect(t, out[0].String(), "b...")
+	out[0] = reflect.ValueOf("changed")
+	out, _ = inj.InvokeMemo(dots)
+	expect(t, out[0].String(), "b...")
+}
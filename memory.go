@@ -0,0 +1,153 @@
+package inject
+
+import (
+	"reflect"
+	"sort"
+)
+
+// MemoryUsage estimates the memory retained by the value of a binding, see
+// Injector.MemoryReport.
+type MemoryUsage struct {
+	// Type is the bound type.
+	Type reflect.Type
+	// Shallow is the size of the value, plus the size of the value it points
+	// to if it is a pointer.
+	Shallow uintptr
+	// Deep is the size of everything reachable from the value through
+	// pointers, slices, maps, strings and interfaces, including Shallow. It is
+	// only computed by MemoryReport(true), and counts memory shared between
+	// bindings for each of them.
+	Deep uintptr
+}
+
+// Size returns Deep if it has been computed, and otherwise Shallow.
+func (u MemoryUsage) Size() uintptr {
+	if u.Deep > 0 {
+		return u.Deep
+	}
+	return u.Shallow
+}
+
+// MemoryReport estimates the memory retained by every value bound in the
+// injector, not its parents, largest first. Provided types are only included
+// once they have been constructed, and pooled types are not included. Deep
+// sizing walks the whole object graph of every value, so it is opt-in.
+func (inj *injector) MemoryReport(deep bool) []MemoryUsage {
+	values := inj.Export()
+	report := make([]MemoryUsage, 0, len(values))
+	for t, v := range values {
+		if v.Kind() == reflect.Interface {
+			v = v.Elem()
+		}
+		u := MemoryUsage{Type: t}
+		if v.IsValid() {
+			u.Shallow = v.Type().Size()
+			if v.Kind() == reflect.Ptr && !v.IsNil() {
+				u.Shallow += v.Type().Elem().Size()
+			}
+			if deep {
+				u.Deep = v.Type().Size() + indirectSize(v, make(map[uintptr]bool))
+			}
+		}
+		report = append(report, u)
+	}
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].Size() != report[j].Size() {
+			return report[i].Size() > report[j].Size()
+		}
+		return report[i].Type.String() < report[j].Type.String()
+	})
+	return report
+}
+
+// indirectSize returns the size of the memory reachable from v, not counting
+// v itself. Pointers, slices, maps and channels in seen are not followed again.
+func indirectSize(v reflect.Value, seen map[uintptr]bool) uintptr {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() || visited(v.Pointer(), seen) {
+			return 0
+		}
+		return v.Type().Elem().Size() + indirectSize(v.Elem(), seen)
+	case reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+		e := v.Elem()
+		return e.Type().Size() + indirectSize(e, seen)
+	case reflect.String:
+		return uintptr(v.Len())
+	case reflect.Slice:
+		if v.IsNil() || visited(v.Pointer(), seen) {
+			return 0
+		}
+		n := uintptr(v.Cap()) * v.Type().Elem().Size()
+		if hasPointers(v.Type().Elem()) {
+			for i := 0; i < v.Len(); i++ {
+				n += indirectSize(v.Index(i), seen)
+			}
+		}
+		return n
+	case reflect.Array:
+		var n uintptr
+		if hasPointers(v.Type().Elem()) {
+			for i := 0; i < v.Len(); i++ {
+				n += indirectSize(v.Index(i), seen)
+			}
+		}
+		return n
+	case reflect.Struct:
+		var n uintptr
+		for i := 0; i < v.NumField(); i++ {
+			n += indirectSize(v.Field(i), seen)
+		}
+		return n
+	case reflect.Map:
+		if v.IsNil() || visited(v.Pointer(), seen) {
+			return 0
+		}
+		t := v.Type()
+		n := uintptr(v.Len()) * (t.Key().Size() + t.Elem().Size())
+		if hasPointers(t.Key()) || hasPointers(t.Elem()) {
+			iter := v.MapRange()
+			for iter.Next() {
+				n += indirectSize(iter.Key(), seen) + indirectSize(iter.Value(), seen)
+			}
+		}
+		return n
+	case reflect.Chan:
+		if v.IsNil() || visited(v.Pointer(), seen) {
+			return 0
+		}
+		return uintptr(v.Cap()) * v.Type().Elem().Size()
+	}
+	return 0
+}
+
+func visited(p uintptr, seen map[uintptr]bool) bool {
+	if seen[p] {
+		return true
+	}
+	seen[p] = true
+	return false
+}
+
+// hasPointers reports whether values of t can reference other memory.
+func hasPointers(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return false
+	case reflect.Array:
+		return hasPointers(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if hasPointers(t.Field(i).Type) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
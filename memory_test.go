@@ -0,0 +1,35 @@
+package inject
+
+import (
+	"testing"
+	"unsafe"
+)
+
+type cacheEntries struct {
+	data  []byte
+	index map[string]int
+	self  *cacheEntries
+}
+
+func TestInjector_MemoryReport(t *testing.T) {
+	entries := &cacheEntries{data: make([]byte, 1000, 1024), index: map[string]int{"abc": 1}}
+	entries.self = entries
+
+	inj := New()
+	inj.Map(entries, 42)
+	expect(t, inj.Provide(func() *testRepo { return &testRepo{} }), nil)
+
+	report := inj.MemoryReport(false)
+	expect(t, len(report), 2)
+	expect(t, report[0].Type, Type[*cacheEntries]())
+	expect(t, report[0].Shallow, unsafe.Sizeof(entries)+unsafe.Sizeof(*entries))
+	expect(t, report[0].Deep, uintptr(0))
+	expect(t, report[1].Type, Type[int]())
+	expect(t, report[1].Size(), unsafe.Sizeof(0))
+
+	report = inj.MemoryReport(true)
+	deep := report[0].Shallow + 1024 + unsafe.Sizeof("") + unsafe.Sizeof(0) + 3
+	expect(t, report[0].Deep, deep)
+	expect(t, report[0].Size(), deep)
+	expect(t, report[1].Deep, unsafe.Sizeof(0))
+}
@@ -0,0 +1,70 @@
+package inject
+
+// Attr is a key/value attribute of a binding, see Meta.
+type Attr struct {
+	Key, Value string
+}
+
+// Meta returns an attribute to attach to the bindings of the values it is
+// passed to Map, MapProtected or Supply with, e.g.
+// Map(db, inject.Meta("team", "payments")). Attributes describe a binding,
+// such as its owner or data classification, and are reported by OriginOf,
+// GraphDoc and Manifest without affecting resolution. A later attribute with
+// the same key replaces an earlier one.
+func Meta(key, value string) Attr {
+	return Attr{Key: key, Value: value}
+}
+
+// WithMeta attaches the attribute key=value to the bindings of the provided
+// types, like Meta does for mapped values.
+func WithMeta(key, value string) ProvideOption {
+	return func(p *provider) {
+		p.meta = withAttr(p.meta, Attr{Key: key, Value: value})
+	}
+}
+
+// withAttr returns a copy of meta with a set.
+func withAttr(meta map[string]string, a Attr) map[string]string {
+	m := make(map[string]string, len(meta)+1)
+	for k, v := range meta {
+		m[k] = v
+	}
+	m[a.Key] = a.Value
+	return m
+}
+
+// splitMeta separates the attributes from the values passed to a
+// registration method. values is returned as is if it has no attributes.
+func splitMeta(values []interface{}) ([]interface{}, map[string]string) {
+	n := 0
+	for _, val := range values {
+		if _, ok := val.(Attr); ok {
+			n++
+		}
+	}
+	if n == 0 {
+		return values, nil
+	}
+	meta := make(map[string]string, n)
+	rest := make([]interface{}, 0, len(values)-n)
+	for _, val := range values {
+		if a, ok := val.(Attr); ok {
+			meta[a.Key] = a.Value
+		} else {
+			rest = append(rest, val)
+		}
+	}
+	return rest, meta
+}
+
+// copyMeta returns a copy of meta, or nil if it is empty.
+func copyMeta(meta map[string]string) map[string]string {
+	if len(meta) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(meta))
+	for k, v := range meta {
+		m[k] = v
+	}
+	return m
+}
@@ -0,0 +1,25 @@
+//go:build !inject_light
+
+package inject
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMeta_Exports(t *testing.T) {
+	inj := New()
+	inj.Map(&greeter{"Jeremy"}, Meta("team", "payments"))
+
+	bindings, _ := NewGraphDoc(inj).Graph()
+	expect(t, bindings[0].Meta["team"], "payments")
+
+	m, err := inj.Manifest()
+	expect(t, err, nil)
+	expect(t, strings.Contains(string(m), `"team": "payments"`), true)
+
+	// Attributes do not change the wiring.
+	other := New()
+	other.Map(&greeter{"Jeremy"})
+	expect(t, other.VerifyManifest(m), nil)
+}
@@ -0,0 +1,37 @@
+package inject
+
+import (
+	"testing"
+)
+
+func TestMeta(t *testing.T) {
+	inj := New()
+	inj.Map(&greeter{"Jeremy"}, Meta("team", "payments"), "plain", Meta("pii", "no"))
+	expect(t, inj.Value(Type[Attr]()).IsValid(), false)
+
+	info, ok := inj.OriginOf(Type[*greeter]())
+	expect(t, ok, true)
+	expect(t, len(info.Meta), 2)
+	expect(t, info.Meta["team"], "payments")
+	expect(t, info.Meta["pii"], "no")
+	info.Meta["team"] = "changed"
+	info, _ = inj.Child().OriginOf(Type[string]())
+	expect(t, info.Meta["team"], "payments")
+	expect(t, info.Level, 1)
+
+	inj.Map(1)
+	info, _ = inj.OriginOf(Type[int]())
+	expect(t, info.Meta == nil, true)
+
+	expect(t, inj.Provide(func() float64 { return 1 }, WithMeta("team", "risk"), WithMeta("tier", "1")), nil)
+	info, _ = inj.OriginOf(Type[float64]())
+	expect(t, info.Meta["team"], "risk")
+	expect(t, info.Meta["tier"], "1")
+
+	expect(t, inj.Supply(func() {}, Meta("team", "core")), nil)
+	info, _ = inj.OriginOf(Type[func()]())
+	expect(t, info.Meta["team"], "core")
+	inj.MapProtected(uint(1), Meta("team", "core"))
+	info, _ = inj.OriginOf(Type[uint]())
+	expect(t, info.Meta["team"], "core")
+}
@@ -0,0 +1,66 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// NamedFunc is a function annotated with the names of its results by
+// NameResults, to be called with InvokeNamed.
+type NamedFunc struct {
+	Func interface{}
+	// Names holds the name of every result of Func. Results named "" or "_"
+	// are left out of the results of InvokeNamed.
+	Names []string
+}
+
+// NameResults annotates f with the names of its results, in order.
+func NameResults(f interface{}, names ...string) NamedFunc {
+	return NamedFunc{Func: f, Names: names}
+}
+
+func (inj *injector) InvokeNamed(f interface{}, opts ...InvokeOption) (map[string]reflect.Value, error) {
+	nf, annotated := f.(NamedFunc)
+	if annotated {
+		f = nf.Func
+	}
+	if err := checkFunc(f); err != nil {
+		return nil, err
+	}
+	t := reflect.TypeOf(f)
+	var fields reflect.Type
+	switch {
+	case annotated:
+		if len(nf.Names) != t.NumOut() {
+			return nil, fmt.Errorf("inject: %d names for the %d results of %v", len(nf.Names), t.NumOut(), t)
+		}
+	case t.NumOut() == 1 && t.Out(0).Kind() == reflect.Struct,
+		t.NumOut() == 2 && t.Out(0).Kind() == reflect.Struct && t.Out(1) == errType:
+		fields = t.Out(0)
+	default:
+		return nil, fmt.Errorf("inject: results of %v are not named, see NameResults", t)
+	}
+
+	out, err := inj.Invoke(f, opts...)
+	if err != nil {
+		return nil, err
+	}
+	named := make(map[string]reflect.Value, len(out))
+	if annotated {
+		for i, name := range nf.Names {
+			if name != "" && name != "_" {
+				named[name] = out[i]
+			}
+		}
+		return named, nil
+	}
+	for i := 0; i < fields.NumField(); i++ {
+		if sf := fields.Field(i); sf.PkgPath == "" && sf.Type != outType {
+			named[sf.Name] = out[0].Field(i)
+		}
+	}
+	if len(out) == 2 {
+		named["error"] = out[1]
+	}
+	return named, nil
+}
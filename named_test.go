@@ -0,0 +1,51 @@
+package inject
+
+import (
+	"errors"
+	"testing"
+)
+
+type stageResult struct {
+	Out
+	Rendered string
+	Length   int
+	skipped  bool
+}
+
+func TestInvokeNamed(t *testing.T) {
+	inj := New()
+	inj.Map("Jeremy")
+
+	named, err := inj.InvokeNamed(NameResults(func(name string) (string, int, error) {
+		return "Hello " + name, len(name), nil
+	}, "greeting", "length", "_"))
+	expect(t, err, nil)
+	expect(t, len(named), 2)
+	expect(t, named["greeting"].String(), "Hello Jeremy")
+	expect(t, named["length"].Int(), int64(6))
+
+	named, err = inj.InvokeNamed(func(name string) (stageResult, error) {
+		return stageResult{Rendered: name, Length: len(name)}, errors.New("partial")
+	})
+	expect(t, err, nil)
+	expect(t, len(named), 3)
+	expect(t, named["Rendered"].String(), "Jeremy")
+	expect(t, named["Length"].Int(), int64(6))
+	expect(t, named["error"].Interface().(error).Error(), "partial")
+
+	named, err = inj.InvokeNamed(NameResults(func(n int) int { return n }, "n"), WithValue(3))
+	expect(t, err, nil)
+	expect(t, named["n"].Int(), int64(3))
+}
+
+func TestInvokeNamed_Errors(t *testing.T) {
+	inj := New()
+	_, err := inj.InvokeNamed(func() (int, int) { return 0, 0 })
+	expect(t, err != nil, true)
+	_, err = inj.InvokeNamed(NameResults(func() (int, int) { return 0, 0 }, "a"))
+	expect(t, err != nil, true)
+	_, err = inj.InvokeNamed(NameResults("not a function"))
+	expect(t, errors.Is(err, ErrNotFunction), true)
+	_, err = inj.InvokeNamed(NameResults(func(s string) int { return 0 }, "n"))
+	expect(t, errors.Is(err, ErrValueNotFound), true)
+}
@@ -0,0 +1,143 @@
+package inject
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// Option configures an Injector created by New or Injector.Child.
+type Option func(*options)
+
+type options struct {
+	applyMethods bool
+	callSites    bool
+	trackUsage   bool
+	stats        bool
+	clock        bool
+	trace        *tracer
+	leaks        *leakDetector
+	recorder     *Recorder
+	firstUse     func(FirstUse)
+	resolveHook  func(reflect.Type, reflect.Value, error)
+	auditSize    int
+	errorsOnly   bool
+	// freezeResolved makes resolved bindings immutable, see
+	// WithFreezeAfterResolve.
+	freezeResolved bool
+	conflicts      func(Conflict)
+	authorize      func(ResolutionRequest) error
+	// label is only read when an injector is created, as labels are not
+	// inherited by children.
+	label string
+	// aliases maps defined types to the types they fall back to.
+	aliases map[reflect.Type]reflect.Type
+	// denied holds the types that must not be resolved, see Deny.
+	denied map[reflect.Type]bool
+	// transforms maps types to the function their values are passed through
+	// on resolution, see Transform.
+	transforms map[reflect.Type]func(reflect.Value) reflect.Value
+	// limits maps the code pointers of functions to the semaphores limiting
+	// their concurrent invocations, see WithInvokeLimit.
+	limits map[uintptr]chan struct{}
+	// deprecated holds the types to warn about, see Deprecate.
+	deprecated map[reflect.Type]*deprecation
+	logf       func(format string, args ...interface{})
+	// fieldFilter replaces struct tags in selecting the fields Apply injects.
+	fieldFilter *fieldFilter
+	// pprofLabels is non-nil if invocations are labeled for profiling.
+	pprofLabels []string
+}
+
+var defaultOptions = &options{}
+
+// newOptions returns base changed by opts, or base itself if there are none.
+func newOptions(base *options, opts []Option) *options {
+	if len(opts) == 0 {
+		return base
+	}
+	o := *base
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &o
+}
+
+// WithApplyMethods makes Apply also call setter methods of the struct after its
+// tagged fields have been injected. Every exported single-argument method whose
+// name starts with "Set" is called if its argument can be resolved, and every
+// method named by MethodInjector is called with all of its arguments resolved.
+func WithApplyMethods() Option {
+	return func(o *options) {
+		o.applyMethods = true
+	}
+}
+
+// WithCallSites makes the Injector record the file, line and time of every
+// Map, MapTo and Set call, to be shown by WriteReport. Capturing the call site
+// has a cost on every registration, so it is disabled by default.
+func WithCallSites() Option {
+	return func(o *options) {
+		o.callSites = true
+	}
+}
+
+// WithPprofLabels makes Invoke run functions with pprof labels, so that CPU
+// profiles attribute their time to them rather than to reflect.Value.Call. The
+// label "inject.func" is set to the name of the invoked function, and labels
+// holds additional key-value pairs, e.g. a route or job name given to a Child.
+//
+// The labels are added to those of the context of the call, which is the
+// context given to InvokeContext or else the context.Context bound in the
+// injector, and the goroutine gets the labels of that context back once the
+// call returns, as with pprof.Do. Calls without a context are not labeled, so
+// that labels the caller set on its goroutine are kept. WithPprofLabels panics
+// if labels is not a list of key-value pairs.
+func WithPprofLabels(labels ...string) Option {
+	if len(labels)%2 != 0 {
+		panic(fmt.Sprintf("inject: WithPprofLabels with an odd number of labels: %q", labels))
+	}
+	return func(o *options) {
+		o.pprofLabels = append(o.pprofLabels[:len(o.pprofLabels):len(o.pprofLabels)], labels...)
+		if o.pprofLabels == nil {
+			o.pprofLabels = []string{}
+		}
+	}
+}
+
+// WithUsageTracking makes the Injector track which bindings have been
+// resolved, to be reported by UnusedBindings. Tracking allocates a little
+// state for every binding, so it is disabled by default.
+func WithUsageTracking() Option {
+	return func(o *options) {
+		o.trackUsage = true
+	}
+}
+
+// WithTrace makes the Injector write every step of resolving dependencies to
+// w: invoked functions and applied structs, and for every requested type
+// whether it was an exact hit, an interface scan hit, a hop to the parent or a
+// miss. It is meant for debugging wiring and slows down every resolution.
+func WithTrace(w io.Writer) Option {
+	return func(o *options) {
+		o.trace = &tracer{w: w}
+	}
+}
+
+// WithStats makes the Injector and its children count their lookups, to be
+// reported by Stats. Counting costs an atomic operation per lookup step, so
+// it is disabled by default.
+func WithStats() Option {
+	return func(o *options) {
+		o.stats = true
+	}
+}
+
+// WithClock makes New bind Clock to SystemClock, so that time-dependent code
+// can depend on Clock and tests override that single binding with a
+// FakeClock. It has no effect on Child.
+func WithClock() Option {
+	return func(o *options) {
+		o.clock = true
+	}
+}
@@ -0,0 +1,47 @@
+package inject
+
+import (
+	"reflect"
+	"time"
+)
+
+// BindingInfo describes where a binding comes from, see Injector.OriginOf.
+type BindingInfo struct {
+	// Type is the bound type, which differs from the requested one if an
+	// interface is implemented by a binding of a concrete type.
+	Type reflect.Type
+	// Level is the position of Injector in the parent chain, 0 being the
+	// injector OriginOf was called on.
+	Level int
+	// Injector is the injector holding the binding.
+	Injector Injector
+	// Method is the registration method, such as "Map" or "Provide". It is
+	// empty for bindings of Injectors not created by this package.
+	Method string
+	// File and Line locate the registration, and Registered is when it
+	// happened. They are only known for injectors created with WithCallSites.
+	File       string
+	Line       int
+	Registered time.Time
+	// Meta holds the attributes attached to the binding with Meta or
+	// WithMeta, or nil if there are none.
+	Meta map[string]string
+}
+
+func (inj *injector) OriginOf(t reflect.Type) (BindingInfo, bool) {
+	r := inj.locate(t)
+	if !r.Found {
+		return BindingInfo{}, false
+	}
+	info := BindingInfo{Type: r.Bound, Level: r.Level, Injector: r.Injector, Method: r.Method}
+	if i, ok := r.Injector.(*injector); ok {
+		i.mu.RLock()
+		b := i.values[r.Bound]
+		i.mu.RUnlock()
+		info.Meta = copyMeta(b.meta)
+		if s := b.site; s != nil {
+			info.File, info.Line, info.Registered = s.file, s.line, s.time
+		}
+	}
+	return info, true
+}
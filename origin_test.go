@@ -0,0 +1,36 @@
+//go:build !inject_light
+
+package inject
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+func TestInjector_OriginOf(t *testing.T) {
+	root := New(WithCallSites())
+	root.Map(&greeter{"Jeremy"})
+	mid := root.Child()
+	mid.Map("shadowed")
+	leaf := mid.Child()
+	leaf.Map("leaf")
+
+	info, ok := leaf.OriginOf(Type[fmt.Stringer]())
+	expect(t, ok, true)
+	expect(t, info.Type, Type[*greeter]())
+	expect(t, info.Level, 2)
+	expect(t, info.Injector, root)
+	expect(t, info.Method, "Map")
+	expect(t, filepath.Base(info.File), "origin_test.go")
+	expect(t, info.Line, 13)
+	expect(t, info.Registered.IsZero(), false)
+
+	info, ok = leaf.OriginOf(Type[string]())
+	expect(t, ok, true)
+	expect(t, info.Level, 0)
+	expect(t, info.Line, 17)
+
+	_, ok = leaf.OriginOf(Type[int]())
+	expect(t, ok, false)
+}
@@ -0,0 +1,102 @@
+package inject
+
+import (
+	"reflect"
+	"sync"
+)
+
+// structPlan lists the fields of a struct type that Apply injects, so that
+// the struct tags are parsed once per type rather than on every Apply.
+type structPlan struct {
+	fields []planField
+}
+
+type planField struct {
+	index    int
+	typ      reflect.Type
+	tag      fieldTag
+	consumer string
+}
+
+func newStructPlan(t reflect.Type) *structPlan {
+	return newFilteredPlan(t, nil)
+}
+
+// newFilteredPlan returns the plan of t injecting the exported fields for which
+// filter returns true, or the tagged ones if filter is nil.
+func newFilteredPlan(t reflect.Type, filter func(reflect.StructField) bool) *structPlan {
+	p := &structPlan{}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, ok := sf.Tag.Lookup("inject")
+		if filter != nil && sf.PkgPath == "" {
+			ok = filter(sf)
+		}
+		if !ok || sf.PkgPath != "" {
+			continue
+		}
+		p.fields = append(p.fields, planField{
+			index:    i,
+			typ:      sf.Type,
+			tag:      parseTag(tag),
+			consumer: t.String() + "." + sf.Name,
+		})
+	}
+	return p
+}
+
+// planShards is the number of shards of the plan cache. It is a power of two,
+// so that a shard is selected by masking the hash of the type.
+const planShards = 64
+
+// planCache caches the plans of struct types, shared by all injectors. It is
+// lock-striped, so that concurrent Apply calls for different types do not
+// contend on a single lock, and calls for the same type only take a read lock.
+type planCache struct {
+	shards [planShards]planShard
+}
+
+type planShard struct {
+	mu    sync.RWMutex
+	plans map[reflect.Type]*structPlan
+	// Padding keeps the locks of neighbouring shards on different cache
+	// lines, so that taking one does not invalidate the other.
+	_ [64]byte
+}
+
+var plans planCache
+
+// get returns the plan of the struct type t, computing it on first use.
+func (c *planCache) get(t reflect.Type) *structPlan {
+	s := &c.shards[typeHash(t)&(planShards-1)]
+	s.mu.RLock()
+	p, ok := s.plans[t]
+	s.mu.RUnlock()
+	if ok {
+		return p
+	}
+
+	p = newStructPlan(t)
+	s.mu.Lock()
+	if cached, ok := s.plans[t]; ok {
+		p = cached
+	} else {
+		if s.plans == nil {
+			s.plans = make(map[reflect.Type]*structPlan)
+		}
+		s.plans[t] = p
+	}
+	s.mu.Unlock()
+	return p
+}
+
+// typeHash returns the FNV-1a hash of the name of t.
+func typeHash(t reflect.Type) uint32 {
+	h := uint32(2166136261)
+	s := t.String()
+	for i := 0; i < len(s); i++ {
+		h ^= uint32(s[i])
+		h *= 16777619
+	}
+	return h
+}
@@ -0,0 +1,82 @@
+package inject
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+type planStruct struct {
+	Dep1    string        `inject:""`
+	Typed   specialString `inject:"type=string"`
+	Plain   string
+	private string `inject:""`
+}
+
+func TestPlanCache(t *testing.T) {
+	var c planCache
+	pt := Type[planStruct]()
+	p := c.get(pt)
+	expect(t, len(p.fields), 2)
+	expect(t, p.fields[0].consumer, "inject.planStruct.Dep1")
+	expect(t, p.fields[1].index, 1)
+	expect(t, p.fields[1].tag.typeName, "string")
+	expect(t, c.get(pt), p)
+
+	var wg sync.WaitGroup
+	got := make([]*structPlan, 100)
+	for i := range got {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			got[i] = c.get(Type[testStruct]())
+		}(i)
+	}
+	wg.Wait()
+	for _, g := range got {
+		expect(t, g, got[0])
+	}
+}
+
+func TestInjector_Apply_ByValue(t *testing.T) {
+	inj := New()
+	inj.Map("a dep").MapTo("another dep", (*specialString)(nil))
+
+	s := testStruct{}
+	expect(t, inj.Apply(s), nil)
+	expect(t, s.Dep1, "")
+}
+
+// BenchmarkInjector_ApplyParallel measures concurrent Apply calls; run it
+// with -cpu 1,2,4,8 to see it scale across GOMAXPROCS.
+func BenchmarkInjector_ApplyParallel(b *testing.B) {
+	inj := New()
+	inj.Map("a dep").MapTo("another dep", (*specialString)(nil))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		var s testStruct
+		for pb.Next() {
+			_ = inj.Apply(&s)
+		}
+	})
+}
+
+// BenchmarkPlanCache_Parallel measures concurrent plan lookups of many struct
+// types, which are spread over the shards of the cache.
+func BenchmarkPlanCache_Parallel(b *testing.B) {
+	types := []reflect.Type{
+		Type[testStruct](), Type[planStruct](), Type[setterStruct](), Type[greeter](),
+		Type[testRepo](), Type[testCache](), Type[BindingInfo](), Type[Stats](),
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			plans.get(types[i%len(types)])
+			i++
+		}
+	})
+}
@@ -0,0 +1,82 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// WithPanicOnMisuse makes the Injector and its children panic when a method
+// that cannot return an error is misused, e.g. Map on an injector returned by
+// Builder.Build, MapTo with a value that is not a pointer to an interface, or
+// Invoke of a value that is not a function. The panic value is an error
+// matching the sentinel error of the misuse, such as ErrImmutable. This is the
+// default, and restores it for children of an injector created
+// WithErrorsOnly.
+func WithPanicOnMisuse() Option {
+	return func(o *options) {
+		o.errorsOnly = false
+	}
+}
+
+// WithErrorsOnly makes the Injector and its children never panic on misuse.
+// Methods returning an error return it instead, and the chainable methods of
+// TypeMapper leave the bindings unchanged and record the first misuse, to be
+// retrieved with Err. Libraries embedding an injector can use it to keep
+// misuse by their callers from crashing the process.
+func WithErrorsOnly() Option {
+	return func(o *options) {
+		o.errorsOnly = true
+	}
+}
+
+// misuse panics with err, or records it if the injector was created
+// WithErrorsOnly. It returns false for use as the result of a check.
+func (inj *injector) misuse(err error) bool {
+	if !inj.opts.errorsOnly {
+		panic(fmt.Errorf("inject: %w", err))
+	}
+	inj.mu.Lock()
+	if ext := inj.extend(); ext.misused == nil {
+		ext.misused = err
+	}
+	inj.mu.Unlock()
+	return false
+}
+
+// misuseErr panics with err, or returns it if the injector was created
+// WithErrorsOnly.
+func (inj *injector) misuseErr(err error) error {
+	if !inj.opts.errorsOnly {
+		panic(fmt.Errorf("inject: %w", err))
+	}
+	return err
+}
+
+func (inj *injector) Err() error {
+	inj.mu.RLock()
+	defer inj.mu.RUnlock()
+	if ext := inj.extension(); ext != nil {
+		return ext.misused
+	}
+	return nil
+}
+
+// checkFunc returns an error matching ErrNotFunction if f is not a function.
+func checkFunc(f interface{}) error {
+	if t := reflect.TypeOf(f); t == nil || t.Kind() != reflect.Func {
+		return fmt.Errorf("%w: %T", ErrNotFunction, f)
+	}
+	return nil
+}
+
+func (inj *injector) MapToE(val, ifacePtr interface{}) error {
+	t, err := InterfaceOfE(ifacePtr)
+	if err != nil {
+		return err
+	}
+	return inj.setE(t, reflect.ValueOf(val), "MapTo", inj.callSite(1))
+}
+
+func (inj *injector) SetE(typ reflect.Type, val reflect.Value) error {
+	return inj.setE(typ, val, "Set", inj.callSite(1))
+}
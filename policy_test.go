@@ -0,0 +1,74 @@
+package inject
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func recoverErr(f func()) (err error) {
+	defer func() {
+		err, _ = recover().(error)
+	}()
+	f()
+	return nil
+}
+
+func TestWithPanicOnMisuse(t *testing.T) {
+	inj := New()
+	err := recoverErr(func() { inj.MapTo(&greeter{}, (*int)(nil)) })
+	expect(t, errors.Is(err, ErrNotInterfacePointer), true)
+	err = recoverErr(func() { _, _ = inj.Invoke("not a function") })
+	expect(t, errors.Is(err, ErrNotFunction), true)
+	err = recoverErr(func() { _, _ = inj.Invoke(nil) })
+	expect(t, errors.Is(err, ErrNotFunction), true)
+
+	child := New(WithErrorsOnly()).Child(WithPanicOnMisuse())
+	err = recoverErr(func() { _, _ = child.Invoke(nil) })
+	expect(t, errors.Is(err, ErrNotFunction), true)
+}
+
+func TestWithErrorsOnly(t *testing.T) {
+	inj := New(WithErrorsOnly())
+	expect(t, inj.Err(), nil)
+
+	_, err := inj.Invoke("not a function")
+	expect(t, errors.Is(err, ErrNotFunction), true)
+	expect(t, inj.Err(), nil)
+
+	inj.MapTo(&greeter{}, (*int)(nil))
+	expect(t, errors.Is(inj.Err(), ErrNotInterfacePointer), true)
+	expect(t, inj.Value(Type[*greeter]()).IsValid(), false)
+
+	inj.MapProtected("protected")
+	inj.Child().Map("shadow")
+	expect(t, errors.Is(inj.Err(), ErrNotInterfacePointer), true)
+	child := inj.Child()
+	child.Map(1, "shadow")
+	expect(t, errors.Is(child.Err(), ErrProtectedBinding), true)
+	expect(t, child.ValueLocal(Type[int]()).IsValid(), false)
+
+	b := NewBuilder(WithErrorsOnly())
+	frozen := b.Build()
+	frozen.Map(1)
+	frozen.Reset()
+	frozen.SetParent(inj)
+	expect(t, errors.Is(frozen.Err(), ErrImmutable), true)
+	expect(t, frozen.Value(Type[int]()).IsValid(), false)
+}
+
+func TestMapToE(t *testing.T) {
+	inj := New()
+	expect(t, errors.Is(inj.MapToE(&greeter{}, (*int)(nil)), ErrNotInterfacePointer), true)
+	expect(t, inj.MapToE(&greeter{"Jeremy"}, (*fmt.Stringer)(nil)), nil)
+	expect(t, inj.Value(Type[fmt.Stringer]()).IsValid(), true)
+
+	expect(t, inj.SetE(Type[int](), reflect.ValueOf(1)), nil)
+	expect(t, inj.Value(Type[int]()).Int(), int64(1))
+
+	inj.MapProtected("protected")
+	expect(t, errors.Is(inj.Child().SetE(Type[string](), reflect.ValueOf("shadow")), ErrProtectedBinding), true)
+	expect(t, errors.Is(NewBuilder().Build().SetE(Type[int](), reflect.ValueOf(1)), ErrImmutable), true)
+	expect(t, inj.Err(), nil)
+}
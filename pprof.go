@@ -0,0 +1,18 @@
+//go:build !inject_light
+
+package inject
+
+import (
+	"context"
+	"reflect"
+	"runtime/pprof"
+)
+
+// invokeLabeled is invoke with the pprof labels of WithPprofLabels added to
+// those of ctx.
+func (inj *injector) invokeLabeled(ctx context.Context, f interface{}) (out []reflect.Value, err error) {
+	pprof.Do(ctx, pprof.Labels(inj.invokeLabels(f)...), func(context.Context) {
+		out, err = inj.invoke(f)
+	})
+	return out, err
+}
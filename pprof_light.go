@@ -0,0 +1,14 @@
+//go:build inject_light
+
+package inject
+
+import (
+	"context"
+	"reflect"
+)
+
+// invokeLabeled is invoke, as the inject_light build does not link
+// runtime/pprof.
+func (inj *injector) invokeLabeled(ctx context.Context, f interface{}) ([]reflect.Value, error) {
+	return inj.invoke(f)
+}
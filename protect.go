@@ -0,0 +1,80 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+	"sync/atomic"
+)
+
+// MapProtected maps the values like Map, and protects their bindings: a later
+// Map, MapTo, Set or Provide of one of their types in the injector or any of
+// its children panics or fails with ErrProtectedBinding, so that critical
+// bindings such as an auth client cannot be shadowed. Only Reset removes
+// protected bindings.
+func (inj *injector) MapProtected(values ...interface{}) TypeMapper {
+	if !inj.mutable() {
+		return inj
+	}
+	values, meta := splitMeta(values)
+	for _, val := range values {
+		if !inj.mustBindable(reflect.TypeOf(val)) {
+			return inj
+		}
+	}
+	site := inj.callSite(1)
+	inj.mu.Lock()
+	ext := inj.extend()
+	if ext.protected == nil {
+		ext.protected = make(map[reflect.Type]bool)
+	}
+	for _, val := range values {
+		t := reflect.TypeOf(val)
+		inj.audit("MapProtected", t, site)
+		inj.store(t, binding{value: reflect.ValueOf(val), method: "MapProtected", site: site, meta: meta})
+		ext.protected[t] = true
+	}
+	atomic.StoreUint32(&inj.hasProtected, 1)
+	inj.mu.Unlock()
+	inj.notify()
+	return inj
+}
+
+// bindable returns an error matching ErrProtectedBinding if t is protected in
+// the injector or its parents, or matching ErrResolvedBinding if its resolved
+// binding is frozen, see WithFreezeAfterResolve.
+func (inj *injector) bindable(t reflect.Type) error {
+	if err := inj.resolvedBinding(t); err != nil {
+		return err
+	}
+	return inj.protectedBinding(t)
+}
+
+// protectedBinding returns an error matching ErrProtectedBinding if t is
+// protected in inj or its parents.
+func (inj *injector) protectedBinding(t reflect.Type) error {
+	for cur := Injector(inj); cur != nil; {
+		i, ok := cur.(*injector)
+		if !ok {
+			break
+		}
+		if atomic.LoadUint32(&i.hasProtected) != 0 {
+			i.mu.RLock()
+			protected := i.extension().protected[t]
+			i.mu.RUnlock()
+			if protected {
+				return fmt.Errorf("%w: %v", ErrProtectedBinding, t)
+			}
+		}
+		cur = i.loadParent()
+	}
+	return nil
+}
+
+// mustBindable is bindable for registration methods that cannot return an
+// error, handling the misuse if t is not bindable.
+func (inj *injector) mustBindable(t reflect.Type) bool {
+	if err := inj.bindable(t); err != nil {
+		return inj.misuse(err)
+	}
+	return true
+}
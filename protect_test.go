@@ -0,0 +1,43 @@
+package inject
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func expectProtectedPanic(t *testing.T, fn func()) {
+	t.Helper()
+	defer func() {
+		err, _ := recover().(error)
+		expect(t, errors.Is(err, ErrProtectedBinding), true)
+	}()
+	fn()
+}
+
+func TestInjector_MapProtected(t *testing.T) {
+	inj := New()
+	auth := &greeter{"auth"}
+	inj.MapProtected(auth, "audit")
+	child := inj.Child()
+
+	expectProtectedPanic(t, func() { inj.Map(&greeter{"other"}) })
+	expectProtectedPanic(t, func() { child.Map("shadow") })
+	expectProtectedPanic(t, func() { child.Child().Set(Type[*greeter](), reflect.ValueOf(&greeter{})) })
+	expect(t, errors.Is(child.Supply("shadow"), ErrProtectedBinding), true)
+	expect(t, errors.Is(child.Provide(func() (*greeter, int) { return nil, 0 }), ErrProtectedBinding), true)
+
+	_, err := child.Invoke(func(g *greeter, s string) {
+		expect(t, g, auth)
+		expect(t, s, "audit")
+	})
+	expect(t, err, nil)
+
+	child.MapTo(&greeter{"stringer"}, (*fmt.Stringer)(nil))
+	expect(t, child.Value(Type[fmt.Stringer]()).IsValid(), true)
+
+	inj.Reset()
+	inj.Map("replaced")
+	expect(t, inj.Value(Type[string]()).String(), "replaced")
+}
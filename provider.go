@@ -0,0 +1,323 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Out can be embedded in a struct returned by a constructor given to Provide,
+// to bind every exported field of the struct instead of the struct itself.
+type Out struct{}
+
+var (
+	outType = reflect.TypeOf(Out{})
+	errType = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// ProviderError is returned when the constructor of a provided type fails or
+// its arguments cannot be resolved.
+type ProviderError struct {
+	// Type is the type whose resolution ran the constructor.
+	Type reflect.Type
+	// Provider is the name of the constructor.
+	Provider string
+	// Err is the error returned by the constructor, or the error resolving its
+	// arguments.
+	Err error
+	// Attempts is the number of times the constructor was called, 0 if its
+	// arguments could not be resolved.
+	Attempts int
+}
+
+func (e *ProviderError) Error() string {
+	if e.Attempts > 1 {
+		return fmt.Sprintf("provider %s of %v failed after %d attempts: %v", e.Provider, e.Type, e.Attempts, e.Err)
+	}
+	return fmt.Sprintf("provider %s of %v failed: %v", e.Provider, e.Type, e.Err)
+}
+
+func (e *ProviderError) Unwrap() error {
+	return e.Err
+}
+
+// Lifetime controls how often a provider calls its constructor.
+type Lifetime int
+
+const (
+	// Singleton providers call their constructor once, the first time one of
+	// their types is resolved, and share the results. It is the default.
+	Singleton Lifetime = iota
+	// Prototype providers call their constructor on every resolution of one of
+	// their types, so that every consumer gets a fresh instance. Cycles through
+	// their arguments fail with ErrCycle, but a constructor resolving its own
+	// type through the injector recurses, as nothing tells it apart from a
+	// concurrent resolution.
+	Prototype
+	// Weak providers share their results like Singleton, but drop them after
+	// a garbage collection cycle in which they were not resolved, and call
+	// their constructor again on the next resolution. It is meant for large
+	// cached artifacts that can be re-created, under memory pressure.
+	// Consumers still holding a dropped value keep it alive, so that two
+	// instances may exist at the same time.
+	Weak
+)
+
+func (l Lifetime) String() string {
+	switch l {
+	case Singleton:
+		return "singleton"
+	case Prototype:
+		return "prototype"
+	case Weak:
+		return "weak"
+	}
+	return "Lifetime(" + strconv.Itoa(int(l)) + ")"
+}
+
+// ProvideOption configures a constructor given to Provide.
+type ProvideOption func(*provider)
+
+// WithLifetime sets the Lifetime of the provided types.
+func WithLifetime(l Lifetime) ProvideOption {
+	return func(p *provider) {
+		p.lifetime = l
+	}
+}
+
+// provider lazily constructs the values of one or more bindings by calling a
+// constructor once, the first time one of them is resolved.
+type provider struct {
+	// inj is the injector the constructor was provided to, which its arguments
+	// are resolved from.
+	inj  *injector
+	fn   reflect.Value
+	name string
+	outs []providerOut
+
+	lifetime Lifetime
+	retry    RetryPolicy
+	timeout  time.Duration
+	// readiness is the probe of WithReadiness, run by BuildAll.
+	readiness interface{}
+	// meta holds the attributes of WithMeta.
+	meta map[string]string
+
+	// owner is the id of the goroutine constructing the results under mu, to
+	// detect constructors resolving their own type. It is accessed
+	// atomically.
+	owner uint64
+
+	done    uint32 // accessed atomically, set once results are stored
+	mu      sync.Mutex
+	results []reflect.Value
+	took    time.Duration
+	// used is set by resolutions of Weak providers, and cleared by every
+	// garbage collection cycle. It is guarded by mu.
+	used bool
+}
+
+// providerOut describes a value bound by a provider: a result of the
+// constructor, or a field of a result embedding Out.
+type providerOut struct {
+	typ    reflect.Type
+	result int
+	field  int
+}
+
+// newProvider validates constructor and returns a provider for its results.
+func newProvider(inj *injector, constructor interface{}) (*provider, error) {
+	fn := reflect.ValueOf(constructor)
+	if fn.Kind() != reflect.Func {
+		return nil, fmt.Errorf("%w: %T is not a function, use Supply to bind it as a value", ErrInvalidProvider, constructor)
+	}
+	if fn.IsNil() {
+		return nil, fmt.Errorf("%w: %T is nil", ErrInvalidProvider, constructor)
+	}
+	p := &provider{inj: inj, fn: fn, name: funcName(fn)}
+
+	t := fn.Type()
+	seen := make(map[reflect.Type]bool)
+	add := func(out providerOut) error {
+		if seen[out.typ] {
+			return fmt.Errorf("%w: %s returns %v more than once", ErrInvalidProvider, p.name, out.typ)
+		}
+		seen[out.typ] = true
+		p.outs = append(p.outs, out)
+		return nil
+	}
+	for i := 0; i < t.NumOut(); i++ {
+		rt := t.Out(i)
+		if rt == errType {
+			if i != t.NumOut()-1 {
+				return nil, fmt.Errorf("%w: %s returns an error before its last result", ErrInvalidProvider, p.name)
+			}
+			continue
+		}
+		if !isOutStruct(rt) {
+			if err := add(providerOut{typ: rt, result: i, field: -1}); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		for j := 0; j < rt.NumField(); j++ {
+			f := rt.Field(j)
+			if f.PkgPath != "" || f.Type == outType {
+				continue
+			}
+			if err := add(providerOut{typ: f.Type, result: i, field: j}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(p.outs) == 0 {
+		return nil, fmt.Errorf("%w: %s has no results to bind", ErrInvalidProvider, p.name)
+	}
+	return p, nil
+}
+
+// isOutStruct reports whether t is a struct embedding Out.
+func isOutStruct(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if f := t.Field(i); f.Anonymous && f.Type == outType {
+			return true
+		}
+	}
+	return false
+}
+
+// get returns the i-th value of the provider, constructing the values first if
+// needed. t is the type being resolved.
+func (p *provider) get(t reflect.Type, i int, chain *building) (reflect.Value, error) {
+	return p.getWith(t, i, nil, chain)
+}
+
+// getWith is get recording its progress in prog, if not nil. chain holds the
+// constructions the resolution is made for.
+func (p *provider) getWith(t reflect.Type, i int, prog *progress, chain *building) (reflect.Value, error) {
+	if p.lifetime == Prototype {
+		if err := chain.cycle(p, t); err != nil {
+			return reflect.Value{}, err
+		}
+		results, err := p.call(t, prog, chain)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return p.outs[i].from(results), nil
+	}
+	if p.lifetime == Weak {
+		return p.getWeak(t, i, prog, chain)
+	}
+	if atomic.LoadUint32(&p.done) == 0 {
+		if err := chain.cycle(p, t); err != nil {
+			return reflect.Value{}, err
+		}
+		if err := p.lock(t); err != nil {
+			return reflect.Value{}, err
+		}
+		defer p.mu.Unlock()
+		if p.done == 0 {
+			if err := p.construct(t, prog, chain); err != nil {
+				return reflect.Value{}, err
+			}
+		}
+	}
+	return p.value(i), nil
+}
+
+// peek returns the i-th value of the provider if it has been constructed.
+func (p *provider) peek(i int) reflect.Value {
+	if p.lifetime == Weak {
+		return p.peekWeak(i)
+	}
+	if atomic.LoadUint32(&p.done) == 0 {
+		return reflect.Value{}
+	}
+	return p.value(i)
+}
+
+func (p *provider) value(i int) reflect.Value {
+	return p.outs[i].from(p.results)
+}
+
+// from returns the value of out in the results of the constructor.
+func (out providerOut) from(results []reflect.Value) reflect.Value {
+	v := results[out.result]
+	if out.field >= 0 {
+		v = v.Field(out.field)
+	}
+	return v
+}
+
+// construct calls the constructor and stores its results. The caller must
+// hold p.mu.
+func (p *provider) construct(t reflect.Type, prog *progress, chain *building) error {
+	atomic.StoreUint64(&p.owner, goroutineID())
+	defer atomic.StoreUint64(&p.owner, 0)
+	start := time.Now()
+	results, err := p.call(t, prog, chain)
+	if err != nil {
+		return err
+	}
+	p.results = results
+	p.took = time.Since(start)
+	atomic.StoreUint32(&p.done, 1)
+	return nil
+}
+
+// call calls the constructor with its arguments resolved, returning its
+// results. t is the type being resolved, the progress is recorded in prog if
+// it is not nil, and chain holds the constructions the resolution is made
+// for.
+func (p *provider) call(t reflect.Type, prog *progress, chain *building) ([]reflect.Value, error) {
+	ft := p.fn.Type()
+	in := make([]reflect.Value, ft.NumIn())
+	self := &building{p: p, next: chain}
+	for i := range in {
+		argType := ft.In(i)
+		prog.set(fmt.Sprintf("resolving argument %d (%v) of %s", i, argType, p.name))
+		val, err := p.inj.resolveWithin(argType, p.name, self)
+		if err == nil && !val.IsValid() {
+			err = p.inj.missing(argType, p.name)
+		}
+		if err != nil {
+			return nil, &ProviderError{Type: t, Provider: p.name, Err: err}
+		}
+		in[i] = val
+	}
+
+	return p.callRetrying(t, in, prog)
+}
+
+func (inj *injector) Provide(constructor interface{}, opts ...ProvideOption) error {
+	if inj.frozen {
+		return ErrImmutable
+	}
+	p, err := newProvider(inj, constructor)
+	if err != nil {
+		return err
+	}
+	for _, out := range p.outs {
+		if err := inj.bindable(out.typ); err != nil {
+			return err
+		}
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	site := inj.callSite(1)
+	inj.mu.Lock()
+	for i, out := range p.outs {
+		inj.audit("Provide", out.typ, site)
+		inj.store(out.typ, binding{method: "Provide", site: site, provider: p, out: i, meta: p.meta})
+	}
+	inj.mu.Unlock()
+	inj.notify()
+	return nil
+}
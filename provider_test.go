@@ -0,0 +1,173 @@
+package inject
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type testRepo struct {
+	dsn string
+}
+
+type testCache struct {
+	size int
+}
+
+func TestInjector_Provide(t *testing.T) {
+	inj := New()
+	inj.Map("postgres://")
+
+	calls := 0
+	expect(t, inj.Provide(func(dsn string) (*testRepo, *testCache, error) {
+		calls++
+		return &testRepo{dsn: dsn}, &testCache{size: 10}, nil
+	}), nil)
+	expect(t, calls, 0)
+
+	_, err := inj.Invoke(func(repo *testRepo, cache *testCache) {
+		expect(t, repo.dsn, "postgres://")
+		expect(t, cache.size, 10)
+	})
+	expect(t, err, nil)
+	expect(t, calls, 1)
+
+	child := inj.Child()
+	child.Map("sqlite://")
+	expect(t, child.Value(Type[*testRepo]()).Interface().(*testRepo).dsn, "postgres://")
+	expect(t, calls, 1)
+}
+
+type testOut struct {
+	Out
+	Repo   *testRepo
+	Cache  *testCache
+	hidden int
+}
+
+func TestInjector_ProvideOut(t *testing.T) {
+	inj := New()
+	expect(t, inj.Provide(func() testOut {
+		return testOut{Repo: &testRepo{dsn: "a"}, Cache: &testCache{size: 1}}
+	}), nil)
+
+	expect(t, inj.Value(Type[testOut]()).IsValid(), false)
+	expect(t, inj.Value(Type[*testRepo]()).Interface().(*testRepo).dsn, "a")
+	expect(t, inj.Value(Type[*testCache]()).Interface().(*testCache).size, 1)
+	expect(t, inj.Value(Type[int]()).IsValid(), false)
+}
+
+func TestInjector_ProvideErrors(t *testing.T) {
+	inj := New()
+	expect(t, errors.Is(inj.Provide("not a function"), ErrInvalidProvider), true)
+	expect(t, errors.Is(inj.Provide(func() {}), ErrInvalidProvider), true)
+	expect(t, errors.Is(inj.Provide(func() error { return nil }), ErrInvalidProvider), true)
+	expect(t, errors.Is(inj.Provide(func() (error, int) { return nil, 0 }), ErrInvalidProvider), true)
+	expect(t, errors.Is(inj.Provide(func() (int, int) { return 0, 0 }), ErrInvalidProvider), true)
+
+	failure := errors.New("connection refused")
+	fail := true
+	expect(t, inj.Provide(func() (*testRepo, error) {
+		if fail {
+			return nil, failure
+		}
+		return &testRepo{}, nil
+	}), nil)
+	expect(t, inj.Provide(func(repo *testRepo, dsn string) *testCache { return &testCache{} }), nil)
+
+	_, err := inj.Invoke(func(*testRepo) {})
+	expect(t, errors.Is(err, failure), true)
+	var perr *ProviderError
+	expect(t, errors.As(err, &perr), true)
+	expect(t, perr.Type, Type[*testRepo]())
+
+	fail = false
+	_, err = inj.Invoke(func(*testCache) {})
+	expect(t, errors.Is(err, ErrValueNotFound), true)
+	expect(t, strings.Contains(err.Error(), "provider "), true)
+	expect(t, inj.Value(Type[*testCache]()).IsValid(), false)
+
+	var buf bytes.Buffer
+	expect(t, inj.WriteReport(&buf), nil)
+	expect(t, strings.Contains(buf.String(), "constructed("), true)
+	expect(t, strings.Contains(buf.String(), "lazy"), true)
+}
+
+func TestInjector_Supply(t *testing.T) {
+	inj := New()
+	calls := 0
+	factory := func() *testRepo {
+		calls++
+		return &testRepo{}
+	}
+	expect(t, inj.Supply(factory, "dsn"), nil)
+	expect(t, inj.Provide(func(dsn string) *testCache { return &testCache{size: len(dsn)} }), nil)
+
+	got, ok := inj.Value(Type[func() *testRepo]()).Interface().(func() *testRepo)
+	expect(t, ok, true)
+	expect(t, got != nil, true)
+	expect(t, calls, 0)
+	expect(t, inj.Value(Type[*testRepo]()).IsValid(), false)
+	expect(t, inj.Value(Type[*testCache]()).Interface().(*testCache).size, 3)
+
+	err := inj.Supply(1, nil)
+	expect(t, errors.Is(err, ErrNilValue), true)
+	expect(t, inj.Value(Type[int]()).IsValid(), false)
+
+	err = inj.Provide(&testRepo{})
+	expect(t, strings.Contains(err.Error(), "use Supply"), true)
+	var nilFn func() int
+	expect(t, errors.Is(inj.Provide(nilFn), ErrInvalidProvider), true)
+}
+
+func TestInjector_ProvidePrototype(t *testing.T) {
+	inj := New()
+	inj.Map("dsn")
+	calls := 0
+	expect(t, inj.Provide(func(dsn string) (*testRepo, *testCache) {
+		calls++
+		return &testRepo{dsn: dsn}, &testCache{size: calls}
+	}, WithLifetime(Prototype)), nil)
+
+	a := inj.Value(Type[*testRepo]()).Interface().(*testRepo)
+	b := inj.Value(Type[*testRepo]()).Interface().(*testRepo)
+	expect(t, a != b, true)
+	expect(t, a.dsn, "dsn")
+	expect(t, inj.Value(Type[*testCache]()).Interface().(*testCache).size, 3)
+
+	_, err := inj.Invoke(func(r1, r2 *testRepo) {
+		expect(t, r1 != r2, true)
+	})
+	expect(t, err, nil)
+	expect(t, calls, 5)
+
+	var buf bytes.Buffer
+	expect(t, inj.WriteReport(&buf), nil)
+	expect(t, strings.Contains(buf.String(), "prototype"), true)
+	expect(t, Prototype.String(), "prototype")
+	expect(t, Lifetime(7).String(), "Lifetime(7)")
+}
+
+func TestInjector_ProvideConcurrent(t *testing.T) {
+	inj := New()
+	calls := 0
+	expect(t, inj.Provide(func() fmt.Stringer {
+		calls++
+		return &greeter{"Jeremy"}
+	}), nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = inj.Value(reflect.TypeOf((*fmt.Stringer)(nil)).Elem())
+		}()
+	}
+	wg.Wait()
+	expect(t, calls, 1)
+}
@@ -0,0 +1,106 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Resolution is a resolution of a type recorded by a Recorder.
+type Resolution struct {
+	// Type is the qualified name of the requested type.
+	Type string `json:"type"`
+	// Bound is the qualified name of the bound type, which differs from Type
+	// if an interface is implemented by a binding of a concrete type. It is
+	// empty if the type was not found.
+	Bound string `json:"bound,omitempty"`
+	// Level is the position of the injector holding the binding in the parent
+	// chain, 0 being the injector the type was resolved from, or -1 if the
+	// type was not found.
+	Level int `json:"level"`
+	// Method is the registration method of the binding, such as "Map" or
+	// "Provide".
+	Method string `json:"method,omitempty"`
+	// Failed reports whether constructing the value failed.
+	Failed bool `json:"failed,omitempty"`
+}
+
+func (r Resolution) String() string {
+	if r.Level < 0 {
+		return r.Type + " not found"
+	}
+	s := fmt.Sprintf("%s from %s at level %d", r.Type, r.Method, r.Level)
+	if r.Bound != r.Type {
+		s = fmt.Sprintf("%s (bound as %s) from %s at level %d", r.Type, r.Bound, r.Method, r.Level)
+	}
+	if r.Failed {
+		s += " failed"
+	}
+	return s
+}
+
+// Recorder records the sequence of resolutions of the injectors created with
+// WithRecorder, so that golden tests can verify the wiring of a run did not
+// change, without asserting on the values themselves. Resolutions happening
+// concurrently are recorded in the order they complete.
+type Recorder struct {
+	mu          sync.Mutex
+	resolutions []Resolution
+}
+
+// NewRecorder returns an empty Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// WithRecorder makes the Injector and its children record every resolution
+// to r. Recording looks every resolved type up a second time, so it is meant
+// for tests.
+func WithRecorder(r *Recorder) Option {
+	return func(o *options) {
+		o.recorder = r
+	}
+}
+
+func (r *Recorder) record(inj *injector, t reflect.Type, err error) {
+	res := Resolution{Type: qualifiedName(t), Level: -1, Failed: err != nil}
+	if loc := inj.locate(t); loc.Found {
+		res.Bound, res.Level, res.Method = qualifiedName(loc.Bound), loc.Level, loc.Method
+	}
+	r.mu.Lock()
+	r.resolutions = append(r.resolutions, res)
+	r.mu.Unlock()
+}
+
+// Resolutions returns the resolutions recorded so far.
+func (r *Recorder) Resolutions() []Resolution {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Resolution(nil), r.resolutions...)
+}
+
+// Reset discards the recorded resolutions.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	r.resolutions = nil
+	r.mu.Unlock()
+}
+
+// Verify compares the recorded resolutions with golden, a previous recording,
+// and returns an error matching ErrWiringChanged describing the first
+// difference.
+func (r *Recorder) Verify(golden []Resolution) error {
+	got := r.Resolutions()
+	for i := 0; i < len(got) && i < len(golden); i++ {
+		if got[i] != golden[i] {
+			return fmt.Errorf("%w: resolution %d is %v, want %v", ErrWiringChanged, i, got[i], golden[i])
+		}
+	}
+	switch {
+	case len(got) > len(golden):
+		return fmt.Errorf("%w: unexpected resolution %d: %v", ErrWiringChanged, len(golden), got[len(golden)])
+	case len(got) < len(golden):
+		return fmt.Errorf("%w: missing resolution %d: %v", ErrWiringChanged, len(got), golden[len(got)])
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+//go:build !inject_light
+
+package inject
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// WriteTo writes the recorded resolutions to w as one JSON object per line,
+// to be read by ReadResolutions.
+func (r *Recorder) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: w}
+	enc := json.NewEncoder(cw)
+	for _, res := range r.Resolutions() {
+		if err := enc.Encode(res); err != nil {
+			return cw.n, err
+		}
+	}
+	return cw.n, nil
+}
+
+// ReadResolutions reads resolutions written by Recorder.WriteTo.
+func ReadResolutions(rd io.Reader) ([]Resolution, error) {
+	var out []Resolution
+	sc := bufio.NewScanner(rd)
+	for line := 1; sc.Scan(); line++ {
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+		var res Resolution
+		if err := json.Unmarshal(sc.Bytes(), &res); err != nil {
+			return nil, fmt.Errorf("inject: resolutions line %d: %w", line, err)
+		}
+		out = append(out, res)
+	}
+	return out, sc.Err()
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,17 @@
+//go:build inject_light
+
+package inject
+
+import "io"
+
+// WriteTo fails with ErrUnsupported in the inject_light build, which does not
+// link encoding/json.
+func (r *Recorder) WriteTo(w io.Writer) (int64, error) {
+	return 0, ErrUnsupported
+}
+
+// ReadResolutions fails with ErrUnsupported in the inject_light build, see
+// Recorder.WriteTo.
+func ReadResolutions(rd io.Reader) ([]Resolution, error) {
+	return nil, ErrUnsupported
+}
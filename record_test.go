@@ -0,0 +1,60 @@
+//go:build !inject_light
+
+package inject
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRecorder(t *testing.T) {
+	rec := NewRecorder()
+	inj := New(WithRecorder(rec))
+	inj.Map("some dependency")
+	expect(t, inj.Provide(func(s string) *testRepo { return &testRepo{dsn: s} }), nil)
+	child := inj.Child()
+	child.MapTo(&greeter{Name: "Jeremy"}, (*fmt.Stringer)(nil))
+
+	_, err := child.Invoke(func(r *testRepo, s fmt.Stringer) {})
+	expect(t, err, nil)
+	_, err = child.Invoke(func(int) {})
+	expect(t, errors.Is(err, ErrValueNotFound), true)
+
+	got := rec.Resolutions()
+	expect(t, len(got), 4)
+	expect(t, got[0].String(), "string from Map at level 0")
+	expect(t, got[1].String(), "*github.com/juanjiTech/inject/v2.testRepo from Provide at level 1")
+	expect(t, got[2].String(), "fmt.Stringer from MapTo at level 0")
+	expect(t, got[3].String(), "int not found")
+
+	var buf bytes.Buffer
+	n, err := rec.WriteTo(&buf)
+	expect(t, err, nil)
+	expect(t, n, int64(buf.Len()))
+	golden, err := ReadResolutions(&buf)
+	expect(t, err, nil)
+	expect(t, len(golden), 4)
+	expect(t, rec.Verify(golden), nil)
+
+	rec.Reset()
+	child.Map(&testRepo{dsn: "local"})
+	_, _ = child.Invoke(func(r *testRepo, s fmt.Stringer) {})
+	_, _ = child.Invoke(func(int) {})
+	err = rec.Verify(golden)
+	expect(t, errors.Is(err, ErrWiringChanged), true)
+	expect(t, strings.Contains(err.Error(), "resolution 0 is *github.com/juanjiTech/inject/v2.testRepo from Map at level 0"), true)
+
+	rec.Reset()
+	_, _ = child.Invoke(func(fmt.Stringer) {})
+	expect(t, strings.Contains(rec.Verify(golden[2:]).Error(), "missing resolution 1"), true)
+	expect(t, strings.Contains(rec.Verify(nil).Error(), "unexpected resolution 0"), true)
+}
+
+func TestReadResolutions(t *testing.T) {
+	_, err := ReadResolutions(strings.NewReader("{\"type\":\"int\",\"level\":-1}\n\nnot json\n"))
+	expect(t, err != nil, true)
+	expect(t, strings.Contains(err.Error(), "line 3"), true)
+}
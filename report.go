@@ -0,0 +1,82 @@
+package inject
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"text/tabwriter"
+	"time"
+)
+
+// WriteReport writes a table of the bindings of the injector and its parents
+// to w, one line per binding, sorted by type within each level of the parent
+// chain. Level 0 is the injector itself. The status of provided types is
+// "lazy" until they are constructed, and then includes the construction
+// time. The origin and registration time are only known for injectors created
+// with WithCallSites.
+func (inj *injector) WriteReport(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "LEVEL\tTYPE\tMETHOD\tSTATUS\tORIGIN\tREGISTERED")
+	level := 0
+	for cur := Injector(inj); cur != nil; level++ {
+		i, ok := cur.(*injector)
+		if !ok {
+			fmt.Fprintf(tw, "%d\t%T\t-\t-\t-\t-\n", level, cur)
+			break
+		}
+		for _, line := range i.reportLines() {
+			if i.label != "" {
+				fmt.Fprintf(tw, "%d(%s)\t%s\n", level, i.label, line)
+				continue
+			}
+			fmt.Fprintf(tw, "%d\t%s\n", level, line)
+		}
+		cur = i.loadParent()
+	}
+	return tw.Flush()
+}
+
+func (inj *injector) reportLines() []string {
+	inj.mu.RLock()
+	types := make([]reflect.Type, 0, len(inj.values))
+	for t := range inj.values {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i].String() < types[j].String() })
+
+	lines := make([]string, len(types))
+	for i, t := range types {
+		b := inj.values[t]
+		origin, registered := "-", "-"
+		if b.site != nil {
+			origin = fmt.Sprintf("%s:%d", filepath.Base(b.site.file), b.site.line)
+			registered = b.site.time.Format(time.RFC3339)
+		}
+		lines[i] = fmt.Sprintf("%v\t%s\t%s\t%s\t%s", t, b.method, b.status(), origin, registered)
+	}
+	inj.mu.RUnlock()
+	return lines
+}
+
+// status describes whether the value of the binding is available.
+func (b binding) status() string {
+	p := b.provider
+	if p == nil {
+		if b.leasable() {
+			return "pooled"
+		}
+		if b.lease != nil {
+			return "leased"
+		}
+		return "value"
+	}
+	if p.lifetime == Prototype {
+		return "prototype"
+	}
+	if b.peek().IsValid() {
+		return "constructed(" + p.took.String() + ")"
+	}
+	return "lazy"
+}
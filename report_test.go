@@ -0,0 +1,38 @@
+package inject
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestInjector_WriteReport(t *testing.T) {
+	parent := New()
+	parent.Map("a dep")
+
+	inj := New(WithCallSites())
+	inj.SetParent(parent)
+	inj.Map(&greeter{"Jeremy"}).MapTo("another dep", (*specialString)(nil))
+
+	var buf bytes.Buffer
+	expect(t, inj.WriteReport(&buf), nil)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	expect(t, len(lines), 4)
+	expect(t, strings.Fields(lines[0])[1], "TYPE")
+
+	fields := strings.Fields(lines[1])
+	expect(t, fields[0], "0")
+	expect(t, fields[1], "*inject.greeter")
+	expect(t, fields[2], "Map")
+	expect(t, strings.HasPrefix(fields[4], "report_test.go:"), true)
+
+	fields = strings.Fields(lines[2])
+	expect(t, fields[1], "inject.specialString")
+	expect(t, fields[2], "MapTo")
+
+	fields = strings.Fields(lines[3])
+	expect(t, fields[0], "1")
+	expect(t, fields[1], "string")
+	expect(t, fields[4], "-")
+}
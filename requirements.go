@@ -0,0 +1,67 @@
+package inject
+
+import "reflect"
+
+// Requirements returns the types target needs to be injected, in order and
+// without duplicates, without resolving anything: the arguments of a function
+// as for Invoke, with the fields of arguments embedding In, or the tagged
+// fields of a struct or pointer to struct as for Apply. Build tooling can use
+// it to compare the bindings required by all handlers with those provided.
+// Fields filled from a group or selected by a `type=` tag are left out, as they
+// do not require a binding of their own type. Other targets require nothing.
+func Requirements(target interface{}) []reflect.Type {
+	t := reflect.TypeOf(target)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil {
+		return nil
+	}
+	var r requirements
+	switch t.Kind() {
+	case reflect.Func:
+		for i := 0; i < t.NumIn(); i++ {
+			if in := t.In(i); in.Kind() == reflect.Struct && isInStruct(in) {
+				r.addIn(in)
+			} else {
+				r.add(in)
+			}
+		}
+	case reflect.Struct:
+		for _, pf := range plans.get(t).fields {
+			if pf.tag.group == "" && pf.tag.typeName == "" {
+				r.add(pf.typ)
+			}
+		}
+	}
+	return r.types
+}
+
+type requirements struct {
+	types []reflect.Type
+	seen  map[reflect.Type]bool
+}
+
+func (r *requirements) add(t reflect.Type) {
+	if r.seen[t] {
+		return
+	}
+	if r.seen == nil {
+		r.seen = make(map[reflect.Type]bool)
+	}
+	r.seen[t] = true
+	r.types = append(r.types, t)
+}
+
+// addIn adds the fields of the struct type t embedding In.
+func (r *requirements) addIn(t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" || f.Type == inType {
+			continue
+		}
+		if tag := parseTag(f.Tag.Get("inject")); tag.group == "" && tag.typeName == "" {
+			r.add(f.Type)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package inject
+
+import (
+	"fmt"
+	"testing"
+)
+
+type requiredParams struct {
+	In
+	Repo     *testRepo
+	Greeter  fmt.Stringer `inject:"type=*inject.greeter"`
+	Handlers []string     `inject:"group=handlers"`
+}
+
+type requiredFields struct {
+	Name     string       `inject:""`
+	Stringer fmt.Stringer `inject:""`
+	Again    string       `inject:""`
+	Plain    int
+}
+
+func TestRequirements(t *testing.T) {
+	got := Requirements(func(s string, p requiredParams, n int, s2 string) {})
+	expect(t, len(got), 3)
+	expect(t, got[0], Type[string]())
+	expect(t, got[1], Type[*testRepo]())
+	expect(t, got[2], Type[int]())
+
+	got = Requirements(&requiredFields{})
+	expect(t, len(got), 2)
+	expect(t, got[0], Type[string]())
+	expect(t, got[1], Type[fmt.Stringer]())
+
+	expect(t, len(Requirements(requiredFields{})), 2)
+	expect(t, len(Requirements(func() {})), 0)
+	expect(t, len(Requirements(42)), 0)
+	expect(t, len(Requirements(nil)), 0)
+}
@@ -0,0 +1,113 @@
+package inject
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// RetryPolicy tells how often a failing constructor is called again, see
+// WithRetry.
+type RetryPolicy struct {
+	// Attempts is the maximum number of calls of the constructor, including
+	// the first one. Values below 2 disable retrying.
+	Attempts int
+	// Backoff is the delay before the second call. It doubles for every
+	// further call, up to MaxBackoff if it is not zero.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// OnRetry, if not nil, is called with the number of the failed call and
+	// its error before the constructor is called again, e.g. to log the
+	// failure or count it in metrics.
+	OnRetry func(attempt int, err error)
+}
+
+// WithRetry makes a failing constructor be called again according to policy,
+// for dependencies such as service discovery that fail transiently. Only
+// errors returned by the constructor and timeouts are retried, not failures
+// to resolve its arguments. The final error is a *ProviderError telling the
+// number of Attempts.
+func WithRetry(policy RetryPolicy) ProvideOption {
+	return func(p *provider) {
+		p.retry = policy
+	}
+}
+
+// WithTimeout limits the time every call of the constructor may take. A call
+// that does not return in time fails with an error matching
+// context.DeadlineExceeded, and goes on in the background with its results
+// discarded.
+func WithTimeout(d time.Duration) ProvideOption {
+	return func(p *provider) {
+		p.timeout = d
+	}
+}
+
+// callRetrying calls the constructor with the arguments in according to the
+// retry policy, recording the progress in prog if it is not nil.
+func (p *provider) callRetrying(t reflect.Type, in []reflect.Value, prog *progress) ([]reflect.Value, error) {
+	delay := p.retry.Backoff
+	for attempt := 1; ; attempt++ {
+		prog.set("calling " + p.name)
+		results, err := p.callOnce(in)
+		if err == nil {
+			return results, nil
+		}
+		if attempt >= p.retry.Attempts {
+			return nil, &ProviderError{Type: t, Provider: p.name, Err: err, Attempts: attempt}
+		}
+		if p.retry.OnRetry != nil {
+			p.retry.OnRetry(attempt, err)
+		}
+		prog.set(fmt.Sprintf("waiting %v to retry %s after %d attempts", delay, p.name, attempt))
+		time.Sleep(delay)
+		if delay *= 2; p.retry.MaxBackoff > 0 && delay > p.retry.MaxBackoff {
+			delay = p.retry.MaxBackoff
+		}
+	}
+}
+
+// callOnce calls the constructor once and returns its results, or the error
+// it returned as its last result.
+func (p *provider) callOnce(in []reflect.Value) ([]reflect.Value, error) {
+	if p.timeout <= 0 {
+		return p.checkResults(p.fn.Call(in))
+	}
+
+	type result struct {
+		results []reflect.Value
+		panic   interface{}
+	}
+	done := make(chan result, 1)
+	go func() {
+		var r result
+		defer func() {
+			r.panic = recover()
+			done <- r
+		}()
+		r.results = p.fn.Call(in)
+	}()
+
+	timer := time.NewTimer(p.timeout)
+	defer timer.Stop()
+	select {
+	case r := <-done:
+		if r.panic != nil {
+			panic(r.panic)
+		}
+		return p.checkResults(r.results)
+	case <-timer.C:
+		return nil, fmt.Errorf("%w: %s did not return within %v", context.DeadlineExceeded, p.name, p.timeout)
+	}
+}
+
+// checkResults returns the error among the results of the constructor, if any.
+func (p *provider) checkResults(results []reflect.Value) ([]reflect.Value, error) {
+	if n := len(results); n > 0 && p.fn.Type().Out(n-1) == errType {
+		if err, _ := results[n-1].Interface().(error); err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
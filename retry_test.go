@@ -0,0 +1,80 @@
+package inject
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestProvide_WithRetry(t *testing.T) {
+	inj := New()
+	calls := 0
+	var retried []int
+	expect(t, inj.Provide(func() (*testRepo, error) {
+		calls++
+		if calls < 3 {
+			return nil, errors.New("discovery unavailable")
+		}
+		return &testRepo{dsn: "db"}, nil
+	}, WithRetry(RetryPolicy{
+		Attempts: 3,
+		Backoff:  time.Millisecond,
+		OnRetry:  func(attempt int, err error) { retried = append(retried, attempt) },
+	})), nil)
+
+	_, err := inj.Invoke(func(r *testRepo) { expect(t, r.dsn, "db") })
+	expect(t, err, nil)
+	expect(t, calls, 3)
+	expect(t, len(retried), 2)
+	expect(t, retried[1], 2)
+}
+
+func TestProvide_WithRetry_Exhausted(t *testing.T) {
+	inj := New()
+	calls := 0
+	boom := errors.New("boom")
+	expect(t, inj.Provide(func() (*testRepo, error) {
+		calls++
+		return nil, boom
+	}, WithRetry(RetryPolicy{Attempts: 2, Backoff: time.Millisecond, MaxBackoff: time.Millisecond})), nil)
+
+	_, err := inj.Invoke(func(*testRepo) {})
+	var pe *ProviderError
+	expect(t, errors.As(err, &pe), true)
+	expect(t, pe.Attempts, 2)
+	expect(t, errors.Is(err, boom), true)
+	expect(t, calls, 2)
+	expect(t, err.Error(), "provider github.com/juanjiTech/inject/v2.TestProvide_WithRetry_Exhausted.func1 of *inject.testRepo failed after 2 attempts: boom")
+
+	expect(t, inj.Provide(func(int) *testCache { calls++; return nil }, WithRetry(RetryPolicy{Attempts: 5})), nil)
+	_, err = inj.Invoke(func(*testCache) {})
+	expect(t, errors.As(err, &pe), true)
+	expect(t, pe.Attempts, 0)
+	expect(t, calls, 2)
+}
+
+func TestProvide_WithTimeout(t *testing.T) {
+	inj := New()
+	release := make(chan struct{})
+	defer close(release)
+	var calls int32
+	expect(t, inj.Provide(func() *testRepo {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			<-release
+		}
+		return &testRepo{dsn: "db"}
+	}, WithTimeout(10*time.Millisecond), WithRetry(RetryPolicy{Attempts: 2})), nil)
+
+	_, err := inj.Invoke(func(r *testRepo) { expect(t, r.dsn, "db") })
+	expect(t, err, nil)
+	expect(t, atomic.LoadInt32(&calls), int32(2))
+
+	expect(t, inj.Provide(func() *testCache {
+		<-release
+		return nil
+	}, WithTimeout(time.Millisecond)), nil)
+	_, err = inj.Invoke(func(*testCache) {})
+	expect(t, errors.Is(err, context.DeadlineExceeded), true)
+}
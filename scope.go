@@ -0,0 +1,26 @@
+package inject
+
+func (inj *injector) OnScopeEnd(fn func()) {
+	inj.mu.Lock()
+	ext := inj.extend()
+	ext.onEnd = append(ext.onEnd, fn)
+	inj.mu.Unlock()
+}
+
+func (inj *injector) End() {
+	inj.mu.Lock()
+	var onEnd []func()
+	if ext := inj.extension(); ext != nil {
+		onEnd = ext.onEnd
+		ext.onEnd = nil
+		if ext.leak != nil {
+			ext.leak.Stop()
+			ext.leak = nil
+		}
+	}
+	inj.mu.Unlock()
+
+	for i := len(onEnd) - 1; i >= 0; i-- {
+		onEnd[i]()
+	}
+}
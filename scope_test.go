@@ -0,0 +1,27 @@
+package inject
+
+import "testing"
+
+func TestInjector_End(t *testing.T) {
+	parent := New()
+	var ended []string
+	parent.OnScopeEnd(func() { ended = append(ended, "parent") })
+
+	scope := parent.Child()
+	scope.OnScopeEnd(func() { ended = append(ended, "tx") })
+	scope.OnScopeEnd(func() { ended = append(ended, "span") })
+	scope.End()
+	expect(t, len(ended), 2)
+	expect(t, ended[0], "span")
+	expect(t, ended[1], "tx")
+
+	scope.End()
+	expect(t, len(ended), 2)
+
+	_, err := parent.InvokeScoped(func(s string) {}, func(m TypeMapper) {
+		m.Map("id")
+		m.(Injector).OnScopeEnd(func() { ended = append(ended, "call") })
+	})
+	expect(t, err, nil)
+	expect(t, ended[2], "call")
+}
@@ -0,0 +1,213 @@
+package inject
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// ScopeManagerOption configures a ScopeManager created by NewScopeManager.
+type ScopeManagerOption func(*scopeManagerConfig)
+
+type scopeManagerConfig struct {
+	max   int
+	ttl   time.Duration
+	clock Clock
+}
+
+// MaxScopes limits a ScopeManager to n scopes. Creating one more evicts the
+// least recently used scope.
+func MaxScopes(n int) ScopeManagerOption {
+	return func(c *scopeManagerConfig) {
+		c.max = n
+	}
+}
+
+// ScopeTTL makes a ScopeManager evict scopes that have not been used for d.
+// Expired scopes are evicted when the ScopeManager is next used.
+func ScopeTTL(d time.Duration) ScopeManagerOption {
+	return func(c *scopeManagerConfig) {
+		c.ttl = d
+	}
+}
+
+// ScopeClock makes a ScopeManager measure the idle time of scopes with clock,
+// e.g. a FakeClock in tests, instead of SystemClock.
+func ScopeClock(clock Clock) ScopeManagerOption {
+	return func(c *scopeManagerConfig) {
+		c.clock = clock
+	}
+}
+
+// ScopeManager maintains child scopes of an Injector keyed by a value such as
+// a tenant ID or shard, e.g. to hold per-tenant clients. Scopes are created
+// lazily by a setup function, and evicted by MaxScopes and ScopeTTL, running
+// the functions registered with OnScopeEnd of an evicted scope so that its
+// clients can be closed. A ScopeManager is safe for concurrent use.
+type ScopeManager[K comparable] struct {
+	parent  Injector
+	setup   func(key K, scope Injector) error
+	config  scopeManagerConfig
+	mu      sync.Mutex
+	entries map[K]*list.Element
+	lru     *list.List // of *managedScope[K], most recently used first
+}
+
+type managedScope[K comparable] struct {
+	key   K
+	scope Injector
+	err   error
+	ready chan struct{}
+	// settingUp is set while setup runs, which keeps the scope from being
+	// evicted, as ending it waits for ready. It is guarded by the lock of the
+	// manager.
+	settingUp bool
+	lastUsed  time.Time
+}
+
+// NewScopeManager returns a ScopeManager creating the scope of a key as a
+// child of parent, set up by setup, e.g. mapping the clients of a tenant.
+func NewScopeManager[K comparable](parent Injector, setup func(key K, scope Injector) error, opts ...ScopeManagerOption) *ScopeManager[K] {
+	m := &ScopeManager[K]{
+		parent:  parent,
+		setup:   setup,
+		config:  scopeManagerConfig{clock: SystemClock},
+		entries: make(map[K]*list.Element),
+		lru:     list.New(),
+	}
+	for _, opt := range opts {
+		opt(&m.config)
+	}
+	return m
+}
+
+// Get returns the scope of key, creating it if there is none. Concurrent calls
+// for a key without scope create it once. If setup fails, the scope is ended
+// and the error returned, and the next Get tries again. Scopes are not evicted
+// while they are set up, so that setup can get other scopes, which lets the
+// ScopeManager exceed MaxScopes until the setups return.
+func (m *ScopeManager[K]) Get(key K) (Injector, error) {
+	now := m.config.clock.Now()
+	m.mu.Lock()
+	evicted := m.expire(now)
+	e, ok := m.entries[key]
+	var s *managedScope[K]
+	if ok {
+		s = e.Value.(*managedScope[K])
+		s.lastUsed = now
+		m.lru.MoveToFront(e)
+	} else {
+		s = &managedScope[K]{key: key, scope: m.parent.Child(), ready: make(chan struct{}), settingUp: true, lastUsed: now}
+		m.entries[key] = m.lru.PushFront(s)
+		evicted = append(evicted, m.trim()...)
+	}
+	m.mu.Unlock()
+	endScopes(evicted)
+
+	if ok {
+		<-s.ready
+		if s.err != nil {
+			return nil, s.err
+		}
+		return s.scope, nil
+	}
+	s.err = m.setup(key, s.scope)
+	m.mu.Lock()
+	s.settingUp = false
+	if s.err != nil {
+		if e, ok := m.entries[key]; ok && e.Value == s {
+			m.remove(e)
+		}
+	}
+	m.mu.Unlock()
+	if s.err != nil {
+		s.scope.End()
+		close(s.ready)
+		return nil, s.err
+	}
+	close(s.ready)
+	return s.scope, nil
+}
+
+// Evict removes the scope of key, if any, and ends it.
+func (m *ScopeManager[K]) Evict(key K) {
+	m.mu.Lock()
+	var evicted []*managedScope[K]
+	if e, ok := m.entries[key]; ok {
+		evicted = append(evicted, m.remove(e))
+	}
+	m.mu.Unlock()
+	endScopes(evicted)
+}
+
+// Len returns the number of scopes, including expired ones not evicted yet.
+func (m *ScopeManager[K]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lru.Len()
+}
+
+// Close evicts every scope.
+func (m *ScopeManager[K]) Close() {
+	m.mu.Lock()
+	var evicted []*managedScope[K]
+	for e := m.lru.Front(); e != nil; e = m.lru.Front() {
+		evicted = append(evicted, m.remove(e))
+	}
+	m.mu.Unlock()
+	endScopes(evicted)
+}
+
+// expire removes the scopes unused since the TTL before now, and returns them
+// to be ended. The caller must hold the lock.
+func (m *ScopeManager[K]) expire(now time.Time) []*managedScope[K] {
+	if m.config.ttl <= 0 {
+		return nil
+	}
+	var evicted []*managedScope[K]
+	for e := m.lru.Back(); e != nil; {
+		s, prev := e.Value.(*managedScope[K]), e.Prev()
+		if now.Sub(s.lastUsed) < m.config.ttl {
+			break
+		}
+		if !s.settingUp {
+			evicted = append(evicted, m.remove(e))
+		}
+		e = prev
+	}
+	return evicted
+}
+
+// trim removes the least recently used scopes beyond MaxScopes that are not
+// being set up, and returns them to be ended. The caller must hold the lock.
+func (m *ScopeManager[K]) trim() []*managedScope[K] {
+	if m.config.max <= 0 {
+		return nil
+	}
+	var evicted []*managedScope[K]
+	for e := m.lru.Back(); e != nil && m.lru.Len() > m.config.max; {
+		s, prev := e.Value.(*managedScope[K]), e.Prev()
+		if !s.settingUp {
+			evicted = append(evicted, m.remove(e))
+		}
+		e = prev
+	}
+	return evicted
+}
+
+// remove removes the entry e and returns its scope. The caller must hold the
+// lock.
+func (m *ScopeManager[K]) remove(e *list.Element) *managedScope[K] {
+	s := m.lru.Remove(e).(*managedScope[K])
+	delete(m.entries, s.key)
+	return s
+}
+
+// endScopes ends the evicted scopes once they are set up, outside of the lock
+// of the manager, as their OnScopeEnd functions may take time.
+func endScopes[K comparable](scopes []*managedScope[K]) {
+	for _, s := range scopes {
+		<-s.ready
+		s.scope.End()
+	}
+}
@@ -0,0 +1,137 @@
+package inject
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type tenantClient struct {
+	tenant string
+	closed bool
+}
+
+func TestScopeManager(t *testing.T) {
+	app := New()
+	app.Map("a dep")
+	var setups int32
+	m := NewScopeManager(app, func(tenant string, scope Injector) error {
+		atomic.AddInt32(&setups, 1)
+		c := &tenantClient{tenant: tenant}
+		scope.Map(c)
+		scope.OnScopeEnd(func() { c.closed = true })
+		return nil
+	}, MaxScopes(2))
+
+	acme, err := m.Get("acme")
+	expect(t, err, nil)
+	c, err := LoadT[*tenantClient](acme)
+	expect(t, err, nil)
+	expect(t, c.tenant, "acme")
+	s, err := LoadT[string](acme)
+	expect(t, err, nil)
+	expect(t, s, "a dep")
+
+	again, err := m.Get("acme")
+	expect(t, err, nil)
+	expect(t, again, acme)
+	expect(t, atomic.LoadInt32(&setups), int32(1))
+
+	_, _ = m.Get("globex")
+	_, _ = m.Get("acme")
+	_, _ = m.Get("initech")
+	expect(t, m.Len(), 2)
+	expect(t, c.closed, false)
+
+	globex, _ := m.Get("globex")
+	expect(t, atomic.LoadInt32(&setups), int32(4))
+	gc, _ := LoadT[*tenantClient](globex)
+	m.Evict("globex")
+	expect(t, gc.closed, true)
+	expect(t, m.Len(), 1)
+
+	m.Close()
+	expect(t, m.Len(), 0)
+	expect(t, c.closed, true)
+}
+
+func TestScopeManager_NestedSetup(t *testing.T) {
+	var m *ScopeManager[string]
+	m = NewScopeManager(New(), func(tenant string, scope Injector) error {
+		if tenant == "reseller" {
+			// Getting a scope during setup would evict the reseller's.
+			_, err := m.Get("customer")
+			return err
+		}
+		return nil
+	}, MaxScopes(1))
+
+	_, err := m.Get("reseller")
+	expect(t, err, nil)
+	expect(t, m.Len(), 2)
+	_, err = m.Get("other")
+	expect(t, err, nil)
+	expect(t, m.Len(), 1)
+}
+
+func TestScopeManager_TTL(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	ended := 0
+	m := NewScopeManager(New(), func(shard int, scope Injector) error {
+		scope.OnScopeEnd(func() { ended++ })
+		return nil
+	}, ScopeTTL(time.Minute), ScopeClock(clock))
+
+	first, _ := m.Get(1)
+	_, _ = m.Get(2)
+	clock.Advance(40 * time.Second)
+	_, _ = m.Get(1)
+	clock.Advance(40 * time.Second)
+	again, _ := m.Get(1)
+	expect(t, again, first)
+	expect(t, ended, 1)
+	expect(t, m.Len(), 1)
+}
+
+func TestScopeManager_SetupError(t *testing.T) {
+	var calls int32
+	m := NewScopeManager(New(), func(key string, scope Injector) error {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			return errors.New("unavailable")
+		}
+		return nil
+	})
+	scope, err := m.Get("acme")
+	expect(t, scope, nil)
+	expect(t, err.Error(), "unavailable")
+	expect(t, m.Len(), 0)
+
+	scope, err = m.Get("acme")
+	expect(t, err, nil)
+	expect(t, scope != nil, true)
+}
+
+func TestScopeManager_Concurrent(t *testing.T) {
+	var setups int32
+	m := NewScopeManager(New(), func(key int, scope Injector) error {
+		atomic.AddInt32(&setups, 1)
+		time.Sleep(time.Millisecond)
+		scope.Map(key)
+		return nil
+	})
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			scope, err := m.Get(7)
+			expect(t, err, nil)
+			n, _ := LoadT[int](scope)
+			expect(t, n, 7)
+		}()
+	}
+	wg.Wait()
+	expect(t, atomic.LoadInt32(&setups), int32(1))
+}
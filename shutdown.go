@@ -0,0 +1,181 @@
+package inject
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StopFunc stops a component. It should return when ctx is done even if the
+// component has not stopped yet.
+type StopFunc func(ctx context.Context) error
+
+// StopOption configures a stop function registered with Shutdown.Register.
+type StopOption func(*stopHook)
+
+// DependsOn declares that the stop function uses the components registered
+// under names, so it is stopped before any of them.
+func DependsOn(names ...string) StopOption {
+	return func(h *stopHook) {
+		h.dependsOn = append(h.dependsOn, names...)
+	}
+}
+
+// StopTimeout limits the time given to the stop function. It is further bounded
+// by the timeout of the Shutdown.
+func StopTimeout(d time.Duration) StopOption {
+	return func(h *stopHook) {
+		h.timeout = d
+	}
+}
+
+type stopHook struct {
+	name      string
+	fn        StopFunc
+	dependsOn []string
+	timeout   time.Duration
+}
+
+// StopError is the error of a single stop function.
+type StopError struct {
+	Name string
+	Err  error
+}
+
+// ShutdownError reports every stop function that failed during Shutdown.Stop,
+// in the order they were called.
+type ShutdownError struct {
+	Errors []StopError
+}
+
+func (e *ShutdownError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Name + ": " + err.Err.Error()
+	}
+	return "shutdown: " + strings.Join(msgs, "; ")
+}
+
+// Shutdown coordinates stopping the components of an application. Stop
+// functions are called one at a time, dependents before their dependencies
+// and otherwise in reverse order of registration.
+type Shutdown struct {
+	timeout time.Duration
+	mu      sync.Mutex
+	hooks   []*stopHook
+	names   map[string]bool
+	stopped bool
+}
+
+// NewShutdown returns a Shutdown whose Stop gives all stop functions together
+// at most timeout to finish. A timeout of zero means no limit.
+func NewShutdown(timeout time.Duration) *Shutdown {
+	return &Shutdown{
+		timeout: timeout,
+		names:   make(map[string]bool),
+	}
+}
+
+// Register adds fn to be called by Stop under name. It returns an error if the
+// name is already registered or Stop has been called.
+func (s *Shutdown) Register(name string, fn StopFunc, opts ...StopOption) error {
+	h := &stopHook{name: name, fn: fn}
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return fmt.Errorf("shutdown: register %q: already stopped", name)
+	}
+	if s.names[name] {
+		return fmt.Errorf("shutdown: register %q: name already registered", name)
+	}
+	s.names[name] = true
+	s.hooks = append(s.hooks, h)
+	return nil
+}
+
+// Stop calls the registered stop functions and returns a *ShutdownError if any
+// of them failed or did not finish in time. Stop functions that are not
+// reached before the timeout of the Shutdown or ctx expire fail with the
+// context error. Only the first call of Stop calls the stop functions.
+func (s *Shutdown) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	if s.stopped {
+		s.mu.Unlock()
+		return nil
+	}
+	s.stopped = true
+	hooks := s.order()
+	s.mu.Unlock()
+
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	var errs []StopError
+	for _, h := range hooks {
+		if err := h.stop(ctx); err != nil {
+			errs = append(errs, StopError{Name: h.name, Err: err})
+		}
+	}
+	if len(errs) > 0 {
+		return &ShutdownError{Errors: errs}
+	}
+	return nil
+}
+
+// order returns the hooks in the order they are stopped: repeatedly the last
+// registered hook that no remaining hook depends on. Hooks in a dependency
+// cycle are stopped in reverse order of registration.
+func (s *Shutdown) order() []*stopHook {
+	remaining := make([]*stopHook, len(s.hooks))
+	copy(remaining, s.hooks)
+	ordered := make([]*stopHook, 0, len(remaining))
+	for len(remaining) > 0 {
+		used := make(map[string]bool)
+		for _, h := range remaining {
+			for _, name := range h.dependsOn {
+				used[name] = true
+			}
+		}
+		next := len(remaining) - 1
+		for i := len(remaining) - 1; i >= 0; i-- {
+			if !used[remaining[i].name] {
+				next = i
+				break
+			}
+		}
+		ordered = append(ordered, remaining[next])
+		remaining = append(remaining[:next], remaining[next+1:]...)
+	}
+	return ordered
+}
+
+func (h *stopHook) stop(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if h.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.timeout)
+		defer cancel()
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- h.fn(ctx)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
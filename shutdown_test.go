@@ -0,0 +1,62 @@
+package inject
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestShutdown_Stop(t *testing.T) {
+	s := NewShutdown(time.Second)
+
+	var stopped []string
+	stop := func(name string) StopFunc {
+		return func(ctx context.Context) error {
+			stopped = append(stopped, name)
+			return nil
+		}
+	}
+	expect(t, s.Register("db", stop("db")), nil)
+	expect(t, s.Register("server", stop("server"), DependsOn("cache", "db")), nil)
+	expect(t, s.Register("cache", stop("cache"), DependsOn("db")), nil)
+	expect(t, s.Register("metrics", stop("metrics")), nil)
+	expect(t, s.Register("db", stop("db")) != nil, true)
+
+	expect(t, s.Stop(context.Background()), nil)
+	expect(t, len(stopped), 4)
+	expect(t, stopped[0], "metrics")
+	expect(t, stopped[1], "server")
+	expect(t, stopped[2], "cache")
+	expect(t, stopped[3], "db")
+
+	expect(t, s.Stop(context.Background()), nil)
+	expect(t, len(stopped), 4)
+	expect(t, s.Register("late", stop("late")) != nil, true)
+}
+
+func TestShutdown_Errors(t *testing.T) {
+	s := NewShutdown(50 * time.Millisecond)
+
+	failure := errors.New("close failed")
+	hang := func(ctx context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	}
+	expect(t, s.Register("last", func(ctx context.Context) error { return nil }), nil)
+	expect(t, s.Register("slow", hang), nil)
+	expect(t, s.Register("hook", hang, StopTimeout(10*time.Millisecond)), nil)
+	expect(t, s.Register("failing", func(ctx context.Context) error { return failure }), nil)
+
+	err := s.Stop(context.Background())
+	var serr *ShutdownError
+	expect(t, errors.As(err, &serr), true)
+	expect(t, len(serr.Errors), 4)
+	expect(t, serr.Errors[0].Err, failure)
+	expect(t, serr.Errors[1].Name, "hook")
+	expect(t, serr.Errors[1].Err, context.DeadlineExceeded)
+	expect(t, serr.Errors[2].Name, "slow")
+	expect(t, serr.Errors[2].Err, context.DeadlineExceeded)
+	expect(t, serr.Errors[3].Name, "last")
+	expect(t, serr.Errors[3].Err, context.DeadlineExceeded)
+}
@@ -0,0 +1,158 @@
+package inject
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync/atomic"
+	"time"
+)
+
+// WithReadiness sets a probe telling whether the constructed values are ready
+// to be used, e.g. func(ctx context.Context, db *sql.DB) error { return
+// db.PingContext(ctx) }. The probe is a function invoked like InvokeContext
+// by BuildAll after construction, failing if it returns a non-nil error as
+// its last result.
+func WithReadiness(probe interface{}) ProvideOption {
+	return func(p *provider) {
+		p.readiness = probe
+	}
+}
+
+// BuildProgress reports an attempt of BuildAll to construct a provider.
+type BuildProgress struct {
+	// Type is the first type bound by the provider.
+	Type reflect.Type
+	// Provider is the name of the constructor.
+	Provider string
+	// Attempt counts the attempts for the provider, starting at 1.
+	Attempt int
+	// Err is the error of the construction or the readiness probe, nil once
+	// the provider is ready.
+	Err error
+	// Retry is the delay before the next attempt if Err is not nil.
+	Retry time.Duration
+}
+
+// BuildOption configures BuildAll.
+type BuildOption func(*buildConfig)
+
+type buildConfig struct {
+	backoff    time.Duration
+	maxBackoff time.Duration
+	progress   func(BuildProgress)
+}
+
+// BuildBackoff sets the delay before the second attempt to build a provider,
+// doubling for every further attempt up to max. It defaults to 100ms, up to
+// 5s.
+func BuildBackoff(initial, max time.Duration) BuildOption {
+	return func(c *buildConfig) {
+		c.backoff, c.maxBackoff = initial, max
+	}
+}
+
+// BuildLog sets the function called after every attempt to build a provider,
+// e.g. to log that the service is waiting for its database.
+func BuildLog(fn func(BuildProgress)) BuildOption {
+	return func(c *buildConfig) {
+		c.progress = fn
+	}
+}
+
+// BuildAll constructs the values of every singleton provider of the injector,
+// not its parents, in order of type name, and runs their readiness probes. A
+// provider failing to construct or probe is constructed again with backoff
+// until it is ready, so that a service can wait for its dependencies at
+// startup. BuildAll gives up once ctx is done, returning a *ProviderError
+// matching ctx.Err() that tells the last error.
+func (inj *injector) BuildAll(ctx context.Context, opts ...BuildOption) error {
+	cfg := buildConfig{backoff: 100 * time.Millisecond, maxBackoff: 5 * time.Second}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	for _, p := range inj.singletons() {
+		if err := inj.build(ctx, p, &cfg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// singletons returns the singleton providers of the injector, sorted by the
+// name of their first type.
+func (inj *injector) singletons() []*provider {
+	inj.mu.RLock()
+	seen := make(map[*provider]bool)
+	var providers []*provider
+	for _, b := range inj.values {
+		if p := b.provider; p != nil && p.lifetime == Singleton && !seen[p] {
+			seen[p] = true
+			providers = append(providers, p)
+		}
+	}
+	inj.mu.RUnlock()
+	sort.Slice(providers, func(i, j int) bool {
+		return providers[i].outs[0].typ.String() < providers[j].outs[0].typ.String()
+	})
+	return providers
+}
+
+func (inj *injector) build(ctx context.Context, p *provider, cfg *buildConfig) error {
+	t := p.outs[0].typ
+	delay := cfg.backoff
+	for attempt := 1; ; attempt++ {
+		err := inj.buildOnce(ctx, p, t)
+		progress := BuildProgress{Type: t, Provider: p.name, Attempt: attempt, Err: err}
+		if err != nil {
+			progress.Retry = delay
+		}
+		if cfg.progress != nil {
+			cfg.progress(progress)
+		}
+		if err == nil {
+			return nil
+		}
+		p.reset()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			err = fmt.Errorf("%w after %d attempts, last error: %v", ctx.Err(), attempt, err)
+			return &ProviderError{Type: t, Provider: p.name, Err: err, Attempts: attempt}
+		case <-timer.C:
+		}
+		if delay *= 2; delay > cfg.maxBackoff {
+			delay = cfg.maxBackoff
+		}
+	}
+}
+
+// buildOnce constructs the values of p and runs its readiness probe.
+func (inj *injector) buildOnce(ctx context.Context, p *provider, t reflect.Type) error {
+	if _, err := p.getContext(ctx, t, 0, nil); err != nil {
+		return err
+	}
+	if p.readiness == nil {
+		return nil
+	}
+	out, err := inj.InvokeContext(ctx, p.readiness)
+	if err != nil {
+		return err
+	}
+	if len(out) > 0 {
+		err, _ = out[len(out)-1].Interface().(error)
+	}
+	return err
+}
+
+// reset discards the constructed values of p, so that they are constructed
+// again on the next resolution.
+func (p *provider) reset() {
+	p.mu.Lock()
+	p.results = nil
+	atomic.StoreUint32(&p.done, 0)
+	p.mu.Unlock()
+}
@@ -0,0 +1,59 @@
+package inject
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestInjector_BuildAll(t *testing.T) {
+	inj := New()
+	constructed := 0
+	expect(t, inj.Provide(func() *testRepo {
+		constructed++
+		return &testRepo{dsn: "db"}
+	}, WithReadiness(func(ctx context.Context, r *testRepo) error {
+		if constructed < 3 {
+			return errors.New("connection refused")
+		}
+		return nil
+	})), nil)
+	expect(t, inj.Provide(func(r *testRepo) *testCache { return &testCache{size: 1} }), nil)
+	expect(t, inj.Provide(func() int { t.Error("prototype constructed"); return 0 }, WithLifetime(Prototype)), nil)
+
+	var log []BuildProgress
+	err := inj.BuildAll(context.Background(), BuildBackoff(time.Millisecond, 2*time.Millisecond), BuildLog(func(p BuildProgress) {
+		log = append(log, p)
+	}))
+	expect(t, err, nil)
+	expect(t, constructed, 3)
+	expect(t, len(log), 4)
+	expect(t, log[0].Type, Type[*testCache]())
+	expect(t, log[0].Err, nil)
+	expect(t, log[1].Type, Type[*testRepo]())
+	expect(t, log[1].Err.Error(), "connection refused")
+	expect(t, log[1].Retry, time.Millisecond)
+	expect(t, log[2].Retry, 2*time.Millisecond)
+	expect(t, log[3].Attempt, 3)
+	expect(t, log[3].Err, nil)
+
+	_, err = inj.Invoke(func(r *testRepo) {})
+	expect(t, err, nil)
+	expect(t, constructed, 3)
+}
+
+func TestInjector_BuildAll_Deadline(t *testing.T) {
+	inj := New()
+	expect(t, inj.Provide(func() (*testRepo, error) {
+		return nil, errors.New("no route to host")
+	}), nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := inj.BuildAll(ctx, BuildBackoff(time.Millisecond, 5*time.Millisecond))
+	var pe *ProviderError
+	expect(t, errors.As(err, &pe), true)
+	expect(t, errors.Is(err, context.DeadlineExceeded), true)
+	expect(t, pe.Attempts > 1, true)
+}
@@ -0,0 +1,87 @@
+package inject
+
+import "sync/atomic"
+
+// Stats describes the bindings and lookups of an injector, see
+// Injector.Stats. Lookup counts are zero unless the injector was created
+// with WithStats.
+type Stats struct {
+	// Bindings is the number of types bound in the injector.
+	Bindings int
+	// Depth is the number of parents of the injector.
+	Depth int
+	// Lookups is the number of types looked up in the injector, including
+	// lookups passed on by its children.
+	Lookups uint64
+	// Hits is the number of lookups found bound in the injector.
+	Hits uint64
+	// InterfaceScans is the number of interface lookups that scanned the
+	// bindings for an implementation.
+	InterfaceScans uint64
+	// IndexHits is the number of interface lookups answered by the index of
+	// implementations without scanning.
+	IndexHits uint64
+	// MissCacheHits is the number of lookups answered by the cache of types
+	// known to be missing in the parent chain.
+	MissCacheHits uint64
+}
+
+// HitRate returns the fraction of lookups found bound in the injector.
+func (s Stats) HitRate() float64 {
+	if s.Lookups == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Lookups)
+}
+
+// IndexHitRate returns the fraction of interface lookups answered by the
+// index of implementations.
+func (s Stats) IndexHitRate() float64 {
+	if total := s.IndexHits + s.InterfaceScans; total > 0 {
+		return float64(s.IndexHits) / float64(total)
+	}
+	return 0
+}
+
+type counter int
+
+const (
+	countLookups counter = iota
+	countHits
+	countScans
+	countIndexHits
+	countMissCacheHits
+	numCounters
+)
+
+// counters are the lookup counts of an injector, accessed atomically.
+type counters [numCounters]uint64
+
+// add increments the count k. It does nothing on a nil *counters.
+func (c *counters) add(k counter) {
+	if c != nil {
+		atomic.AddUint64(&c[k], 1)
+	}
+}
+
+func (inj *injector) Stats() Stats {
+	inj.mu.RLock()
+	s := Stats{Bindings: len(inj.values)}
+	inj.mu.RUnlock()
+	for cur := inj.loadParent(); cur != nil; s.Depth++ {
+		i, ok := cur.(*injector)
+		if !ok {
+			s.Depth++
+			break
+		}
+		cur = i.loadParent()
+	}
+	if c := inj.counters; c != nil {
+		s.Lookups = atomic.LoadUint64(&c[countLookups])
+		s.Hits = atomic.LoadUint64(&c[countHits])
+		s.InterfaceScans = atomic.LoadUint64(&c[countScans])
+		s.IndexHits = atomic.LoadUint64(&c[countIndexHits])
+		s.MissCacheHits = atomic.LoadUint64(&c[countMissCacheHits])
+	}
+	return s
+}
@@ -0,0 +1,43 @@
+//go:build !inject_light
+
+package inject
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestInjector_Stats(t *testing.T) {
+	parent := New(WithStats())
+	parent.Map("dep", &greeter{"Jeremy"})
+	child := parent.Child()
+	child.Map(1)
+
+	for i := 0; i < 2; i++ {
+		_, err := child.Invoke(func(string, int, fmt.Stringer) {})
+		expect(t, err, nil)
+	}
+	_ = child.Value(Type[bool]())
+	_ = child.Value(Type[bool]())
+
+	s := child.Stats()
+	expect(t, s.Bindings, 1)
+	expect(t, s.Depth, 1)
+	expect(t, s.Lookups, uint64(8))
+	expect(t, s.Hits, uint64(2))
+	expect(t, s.InterfaceScans, uint64(1))
+	expect(t, s.IndexHits, uint64(1))
+	expect(t, s.MissCacheHits, uint64(1))
+	expect(t, s.HitRate(), 0.25)
+	expect(t, s.IndexHitRate(), 0.5)
+
+	p := parent.Stats()
+	expect(t, p.Bindings, 2)
+	expect(t, p.Depth, 0)
+	expect(t, p.Lookups, uint64(5))
+	expect(t, p.Hits, uint64(4))
+
+	s = New().Stats()
+	expect(t, s, Stats{})
+	expect(t, s.HitRate(), 0.0)
+}
@@ -0,0 +1,67 @@
+// Package stdbindings maps sensible defaults from the standard library into an
+// Injector, so that small programs get useful wiring out of the box and tests
+// can override single bindings.
+package stdbindings
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/juanjiTech/inject/v2"
+)
+
+// Stdout is the writer for regular output, os.Stdout by default.
+type Stdout interface {
+	io.Writer
+}
+
+// Stderr is the writer for diagnostic output, os.Stderr by default.
+type Stderr interface {
+	io.Writer
+}
+
+// Map maps the defaults into m and returns it:
+//
+//   - context.Context: context.Background()
+//   - *rand.Rand: a new Rand for every resolution, as a Rand is not safe for
+//     concurrent use, seeded from one seeded with the current time
+//   - io.Writer and Stdout: os.Stdout
+//   - Stderr: os.Stderr
+//   - *http.Client: http.DefaultClient
+//
+// Values mapped into m afterwards, or into its children, take precedence.
+func Map(m inject.TypeMapper) inject.TypeMapper {
+	m.MapTo(context.Background(), (*context.Context)(nil)).
+		MapTo(os.Stdout, (*io.Writer)(nil)).
+		MapTo(os.Stdout, (*Stdout)(nil)).
+		MapTo(os.Stderr, (*Stderr)(nil)).
+		Map(http.DefaultClient)
+	_ = m.Provide(newRand, inject.WithLifetime(inject.Prototype))
+	return m
+}
+
+// seeds seeds the Rands returned by newRand.
+var seeds = struct {
+	sync.Mutex
+	r *rand.Rand
+}{r: rand.New(rand.NewSource(time.Now().UnixNano()))}
+
+// newRand returns a Rand with a source of its own.
+func newRand() *rand.Rand {
+	seeds.Lock()
+	seed := seeds.r.Int63()
+	seeds.Unlock()
+	return rand.New(rand.NewSource(seed))
+}
+
+// New returns a new Injector with the defaults mapped, see Map.
+func New(opts ...inject.Option) inject.Injector {
+	inj := inject.New(opts...)
+	Map(inj)
+	return inj
+}
@@ -0,0 +1,52 @@
+package stdbindings
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/juanjiTech/inject/v2"
+)
+
+/* Test Helpers */
+func expect(t testing.TB, actual interface{}, expect interface{}) {
+	t.Helper()
+	if actual != expect {
+		t.Errorf("Expected %v (type %v) - Got %v (type %v)", expect, reflect.TypeOf(expect), actual, reflect.TypeOf(actual))
+	}
+}
+
+func TestNew(t *testing.T) {
+	inj := New()
+	_, err := inj.Invoke(func(ctx context.Context, r *rand.Rand, w io.Writer, out Stdout, errOut Stderr, c *http.Client) {
+		expect(t, ctx, context.Background())
+		expect(t, r != nil, true)
+		expect(t, w, io.Writer(os.Stdout))
+		expect(t, out, Stdout(os.Stdout))
+		expect(t, errOut, Stderr(os.Stderr))
+		expect(t, c, http.DefaultClient)
+	})
+	expect(t, err, nil)
+
+	r1, _ := inject.LoadT[*rand.Rand](inj)
+	r2, _ := inject.LoadT[*rand.Rand](inj)
+	expect(t, r1 != r2, true)
+}
+
+func TestMap_Override(t *testing.T) {
+	var buf bytes.Buffer
+	scope := New().Child()
+	scope.MapTo(&buf, (*Stdout)(nil))
+	_, err := scope.Invoke(func(out Stdout) {
+		_, _ = io.WriteString(out, "captured")
+	})
+	expect(t, err, nil)
+	expect(t, buf.String(), "captured")
+
+	expect(t, Map(inject.New()).Value(inject.Type[*http.Client]()).IsValid(), true)
+}
@@ -0,0 +1,82 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// fieldTag holds the options of an `inject` struct tag, a comma separated
+// list of key=value pairs. Unknown keys are ignored.
+type fieldTag struct {
+	// typeName selects the binding of the named concrete type, see
+	// qualifiedName.
+	typeName string
+	// group fills a slice with the values of the named group.
+	group string
+}
+
+func parseTag(tag string) fieldTag {
+	var ft fieldTag
+	for _, opt := range strings.Split(tag, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(opt), "=")
+		switch key {
+		case "type":
+			ft.typeName = value
+		case "group":
+			ft.group = value
+		}
+	}
+	return ft
+}
+
+// qualifiedName returns the name of t qualified by the full import path of
+// its package, such as "*github.com/acme/cache.Redis".
+func qualifiedName(t reflect.Type) string {
+	if t.Kind() == reflect.Ptr {
+		return "*" + qualifiedName(t.Elem())
+	}
+	if t.PkgPath() == "" {
+		return t.String()
+	}
+	return t.PkgPath() + "." + t.Name()
+}
+
+// typeNamed returns the bound type named name in the injector or its parents,
+// by its short name such as "*cache.Redis" or its qualified name.
+func (inj *injector) typeNamed(name string) reflect.Type {
+	for _, entry := range chainEntries(inj) {
+		if entry.typ.String() == name || qualifiedName(entry.typ) == name {
+			return entry.typ
+		}
+	}
+	return nil
+}
+
+// resolveField resolves the value of a struct field of type ft tagged with
+// tag, for the constructions in chain.
+func (inj *injector) resolveField(ft reflect.Type, tag fieldTag, consumer string, chain *building) (reflect.Value, error) {
+	if tag.group != "" {
+		return inj.resolveGroup(ft, tag.group, consumer)
+	}
+	if tag.typeName == "" {
+		v, err := inj.resolveWithin(ft, consumer, chain)
+		if err == nil && !v.IsValid() {
+			err = inj.missing(ft, consumer)
+		}
+		return v, err
+	}
+
+	bt := inj.typeNamed(tag.typeName)
+	if bt == nil {
+		return reflect.Value{}, fmt.Errorf("%w: %s (required by %s)", ErrValueNotFound, tag.typeName, consumer)
+	}
+	if !bt.AssignableTo(ft) {
+		return reflect.Value{}, fmt.Errorf("%w: %v is not assignable to %v (required by %s)", ErrValueCanNotSet, bt, ft, consumer)
+	}
+	v, err := inj.resolveWithin(bt, consumer, chain)
+	if err == nil && !v.IsValid() {
+		err = inj.missing(bt, consumer)
+	}
+	return v, err
+}
@@ -0,0 +1,46 @@
+package inject
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type taggedStruct struct {
+	Short  fmt.Stringer `inject:"type=*inject.greeter"`
+	Full   fmt.Stringer `inject:"type=*github.com/juanjiTech/inject/v2.valueStringer"`
+	Plain  string       `inject:""`
+	Unused int
+}
+
+func TestApplyTypeTag(t *testing.T) {
+	inj := New()
+	inj.Map(&greeter{"Jeremy"}, &valueStringer{}, "plain")
+
+	s := taggedStruct{}
+	expect(t, inj.Apply(&s), nil)
+	expect(t, s.Short.(*greeter).Name, "Jeremy")
+	_, ok := s.Full.(*valueStringer)
+	expect(t, ok, true)
+	expect(t, s.Plain, "plain")
+
+	child := inj.Child()
+	s = taggedStruct{}
+	expect(t, child.Apply(&s), nil)
+	expect(t, s.Short.(*greeter).Name, "Jeremy")
+
+	var missing struct {
+		S fmt.Stringer `inject:"type=*inject.testRepo"`
+	}
+	expect(t, errors.Is(New().Apply(&missing), ErrValueNotFound), true)
+
+	var wrong struct {
+		S fmt.Stringer `inject:"type=string"`
+	}
+	expect(t, errors.Is(inj.Apply(&wrong), ErrValueCanNotSet), true)
+}
+
+func TestParseTag(t *testing.T) {
+	expect(t, parseTag(""), fieldTag{})
+	expect(t, parseTag("type=*pkg.T, other=x"), fieldTag{typeName: "*pkg.T"})
+}
@@ -0,0 +1,121 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+// templateSlots is the number of types whose values a ScopeTemplate stores in
+// the same allocation as the scope.
+const templateSlots = 8
+
+// ScopeTemplate creates request scopes binding a fixed set of types, e.g. the
+// *http.Request and http.ResponseWriter of a web server. It is configured
+// once, with NewScopeTemplate, and NewScope then creates a child scope holding
+// the values of a request in a single allocation for up to 8 types, without
+// the map operations of Map. A ScopeTemplate is safe for concurrent use.
+type ScopeTemplate struct {
+	parent Injector
+	// ref is the parentRef of parent shared by the scopes.
+	ref   *parentRef
+	opts  *options
+	types []reflect.Type
+}
+
+// templateScope is the allocation of a scope created by a ScopeTemplate.
+type templateScope struct {
+	inj   injector
+	ext   extension
+	slots [templateSlots]reflect.Value
+}
+
+// NewScopeTemplate returns a ScopeTemplate creating children of parent that
+// bind types. The scopes have the options of parent, like those returned by
+// Child. It panics if a type is nil, given twice or protected with
+// MapProtected in the parent chain.
+func NewScopeTemplate(parent Injector, types ...reflect.Type) *ScopeTemplate {
+	p, _ := parent.(*injector)
+	seen := make(map[reflect.Type]bool, len(types))
+	for _, t := range types {
+		if t == nil {
+			panic("inject: NewScopeTemplate with a nil type")
+		}
+		if seen[t] {
+			panic(fmt.Sprintf("inject: NewScopeTemplate with %v twice", t))
+		}
+		seen[t] = true
+		if p != nil {
+			if err := p.protectedBinding(t); err != nil {
+				panic(fmt.Errorf("inject: %w", err))
+			}
+		}
+	}
+	opts := defaultOptions
+	if p != nil {
+		opts = p.opts
+	}
+	return &ScopeTemplate{parent: parent, ref: refOf(parent), opts: opts, types: append([]reflect.Type(nil), types...)}
+}
+
+// Types returns the types bound by the scopes of the template.
+func (st *ScopeTemplate) Types() []reflect.Type {
+	return append([]reflect.Type(nil), st.types...)
+}
+
+// NewScope returns a child scope of the template's parent binding the types of
+// the template to values, given in the same order. A nil value leaves its
+// type unbound, so that it resolves from the parent. Values bound by NewScope
+// take precedence over later registrations of their types in the scope, and
+// resolve requests of their exact type only, not of interfaces they
+// implement. It panics if the number of values differs from the number of
+// types, or a value is not assignable to its type.
+func (st *ScopeTemplate) NewScope(values ...interface{}) Injector {
+	if len(values) != len(st.types) {
+		panic(fmt.Sprintf("inject: NewScope with %d values for %d types", len(values), len(st.types)))
+	}
+	block := &templateScope{}
+	inj := &block.inj
+	inj.self.Injector = inj
+	inj.opts = st.opts
+	inj.template = st
+	inj.ext = unsafe.Pointer(&block.ext)
+	slots := block.slots[:]
+	if len(st.types) > templateSlots {
+		slots = make([]reflect.Value, len(st.types))
+	}
+	slots = slots[:len(st.types)]
+	block.ext.slots = slots
+	for i, val := range values {
+		if val == nil {
+			continue
+		}
+		v := reflect.ValueOf(val)
+		if !v.Type().AssignableTo(st.types[i]) {
+			panic(fmt.Sprintf("inject: NewScope with %v for %v", v.Type(), st.types[i]))
+		}
+		slots[i] = v
+	}
+	inj.parent = unsafe.Pointer(st.ref)
+	if inj.opts.stats {
+		inj.counters = &counters{}
+	}
+	if inj.opts.leaks != nil {
+		inj.watch(inj.opts.leaks)
+	}
+	return inj
+}
+
+// slotBinding returns the binding of t to the value given to NewScope, if inj
+// was created by a ScopeTemplate binding t.
+func (inj *injector) slotBinding(t reflect.Type) binding {
+	if inj.template == nil {
+		return binding{}
+	}
+	for i, st := range inj.template.types {
+		if st == t {
+			return binding{value: inj.extension().slots[i], method: "ScopeTemplate"}
+		}
+	}
+	return binding{}
+}
@@ -0,0 +1,110 @@
+package inject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type templateRequest struct{ path string }
+
+func TestScopeTemplate(t *testing.T) {
+	app := New()
+	app.Map(&greeter{"Jeremy"}, "app")
+	tmpl := NewScopeTemplate(app, Type[*templateRequest](), Type[context.Context](), Type[string]())
+	expect(t, len(tmpl.Types()), 3)
+
+	ctx := context.WithValue(context.Background(), ctxKeyTemplate{}, "v")
+	req := &templateRequest{path: "/a"}
+	scope := tmpl.NewScope(req, ctx, nil)
+	_, err := scope.Invoke(func(r *templateRequest, c context.Context, s string, g *greeter) {
+		expect(t, r, req)
+		expect(t, c.Value(ctxKeyTemplate{}), "v")
+		expect(t, s, "app")
+		expect(t, g.Name, "Jeremy")
+	})
+	expect(t, err, nil)
+
+	// Scopes are independent and can be extended.
+	other := tmpl.NewScope(&templateRequest{path: "/b"}, ctx, "request")
+	other.Map(1)
+	s, _ := LoadT[string](other)
+	expect(t, s, "request")
+	r, _ := LoadT[*templateRequest](scope)
+	expect(t, r.path, "/a")
+	n, _ := LoadT[int](other)
+	expect(t, n, 1)
+	_, ok := GetG[int](scope)
+	expect(t, ok, false)
+
+	info, ok := other.OriginOf(Type[*templateRequest]())
+	expect(t, ok, true)
+	expect(t, info.Method, "ScopeTemplate")
+	expect(t, other.CanInvoke(func(*templateRequest, int) {}), nil)
+	bindings, _ := NewGraphDoc(other).Graph()
+	expect(t, len(bindings), 6)
+
+	var ended bool
+	other.OnScopeEnd(func() { ended = true })
+	other.End()
+	expect(t, ended, true)
+}
+
+type ctxKeyTemplate struct{}
+
+func TestScopeTemplate_Allocs(t *testing.T) {
+	tmpl := NewScopeTemplate(New(), Type[*templateRequest](), Type[*greeter]())
+	req, g := &templateRequest{}, &greeter{}
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = tmpl.NewScope(req, g)
+	})
+	expect(t, allocs, float64(1))
+}
+
+func TestScopeTemplate_Misuse(t *testing.T) {
+	tmpl := NewScopeTemplate(New(), Type[string]())
+	for _, values := range [][]interface{}{{}, {1}} {
+		func() {
+			defer func() { expect(t, recover() != nil, true) }()
+			tmpl.NewScope(values...)
+		}()
+	}
+	func() {
+		defer func() {
+			expect(t, fmt.Sprint(recover()), "inject: NewScopeTemplate with string twice")
+		}()
+		NewScopeTemplate(New(), Type[string](), reflect.TypeOf(""))
+	}()
+
+	app := New()
+	app.MapProtected("real")
+	defer func() {
+		err, _ := recover().(error)
+		expect(t, errors.Is(err, ErrProtectedBinding), true)
+	}()
+	NewScopeTemplate(app.Child(), Type[string]())
+}
+
+func BenchmarkScopeTemplate_NewScope(b *testing.B) {
+	app := New()
+	tmpl := NewScopeTemplate(app, Type[*templateRequest](), Type[*greeter]())
+	req, g := &templateRequest{}, &greeter{}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		scope := tmpl.NewScope(req, g)
+		_, _ = scope.Invoke(func(*templateRequest, *greeter) {})
+	}
+}
+
+func BenchmarkScopeTemplate_Child(b *testing.B) {
+	app := New()
+	req, g := &templateRequest{}, &greeter{}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		scope := app.Child()
+		scope.Map(req, g)
+		_, _ = scope.Invoke(func(*templateRequest, *greeter) {})
+	}
+}
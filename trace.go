@@ -0,0 +1,33 @@
+package inject
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// tracer writes resolution steps, see WithTrace. All methods do nothing on a
+// nil *tracer.
+type tracer struct {
+	w io.Writer
+}
+
+func (tr *tracer) printf(format string, args ...interface{}) {
+	if tr == nil {
+		return
+	}
+	fmt.Fprintf(tr.w, "inject: "+format+"\n", args...)
+}
+
+// trace writes a step of resolving t at the given level of the parent chain,
+// in the injector with the given label.
+func (tr *tracer) trace(level int, label string, t reflect.Type, format string, args ...interface{}) {
+	if tr == nil {
+		return
+	}
+	if label != "" {
+		tr.printf("[%d %s] %v: %s", level, label, t, fmt.Sprintf(format, args...))
+		return
+	}
+	tr.printf("[%d] %v: %s", level, t, fmt.Sprintf(format, args...))
+}
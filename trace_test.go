@@ -0,0 +1,39 @@
+//go:build !inject_light
+
+package inject
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestWithTrace(t *testing.T) {
+	var buf bytes.Buffer
+	parent := New()
+	parent.Map(&greeter{"Jeremy"})
+	inj := New(WithTrace(&buf))
+	inj.SetParent(parent)
+	inj.Map("a dep")
+
+	_, _ = inj.Invoke(TestWithTrace)
+	got := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	expect(t, got[0], "inject: invoke github.com/juanjiTech/inject/v2.TestWithTrace")
+	expect(t, got[1], "inject: [0] *testing.T: parent hop")
+	expect(t, got[2], "inject: [1] *testing.T: miss")
+
+	buf.Reset()
+	_, err := inj.Invoke(func(string, fmt.Stringer) {})
+	expect(t, err, nil)
+	got = strings.Split(strings.TrimSpace(buf.String()), "\n")
+	expect(t, len(got), 4)
+	expect(t, got[1], "inject: [0] string: exact hit")
+	expect(t, got[2], "inject: [0] fmt.Stringer: parent hop")
+	expect(t, got[3], "inject: [1] fmt.Stringer: interface scan hit *inject.greeter")
+
+	buf.Reset()
+	_ = inj.Apply(&testStruct{})
+	got = strings.Split(strings.TrimSpace(buf.String()), "\n")
+	expect(t, got[0], "inject: apply inject.testStruct")
+}
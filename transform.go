@@ -0,0 +1,24 @@
+package inject
+
+import "reflect"
+
+// Transform returns an Option making the Injector and its children pass every
+// value of type t they resolve through fn, e.g. to hand each consumer its own
+// copy of a mutable configuration struct instead of the shared instance. Unlike
+// a binding, fn runs on every resolution, including those of arguments of
+// providers provided to the injector. fn must return a value assignable to t.
+// Transforms of the same type are applied in the order they were given.
+func Transform(t reflect.Type, fn func(reflect.Value) reflect.Value) Option {
+	return func(o *options) {
+		transforms := make(map[reflect.Type]func(reflect.Value) reflect.Value, len(o.transforms)+1)
+		for typ, f := range o.transforms {
+			transforms[typ] = f
+		}
+		if prev := transforms[t]; prev != nil {
+			transforms[t] = func(v reflect.Value) reflect.Value { return fn(prev(v)) }
+		} else {
+			transforms[t] = fn
+		}
+		o.transforms = transforms
+	}
+}
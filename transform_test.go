@@ -0,0 +1,51 @@
+package inject
+
+import (
+	"reflect"
+	"testing"
+)
+
+type transformConfig struct {
+	Name string
+}
+
+func cloneConfig(v reflect.Value) reflect.Value {
+	c := *v.Interface().(*transformConfig)
+	return reflect.ValueOf(&c)
+}
+
+func TestTransform(t *testing.T) {
+	shared := &transformConfig{Name: "app"}
+	inj := New(Transform(Type[*transformConfig](), cloneConfig))
+	inj.Map(shared)
+
+	_, err := inj.Invoke(func(c *transformConfig) { c.Name = "mutated" })
+	expect(t, err, nil)
+	expect(t, shared.Name, "app")
+
+	c, err := LoadT[*transformConfig](inj.Child())
+	expect(t, err, nil)
+	expect(t, c != shared, true)
+	expect(t, c.Name, "app")
+
+	// Providers are handed transformed arguments too.
+	expect(t, inj.Provide(func(c *transformConfig) string { return c.Name }), nil)
+	s, err := LoadT[string](inj)
+	expect(t, err, nil)
+	expect(t, s, "app")
+
+	// Transforms of children compose with those of the parent.
+	child := inj.Child(Transform(Type[*transformConfig](), func(v reflect.Value) reflect.Value {
+		v.Interface().(*transformConfig).Name += " (child)"
+		return v
+	}))
+	c, err = LoadT[*transformConfig](child)
+	expect(t, err, nil)
+	expect(t, c.Name, "app (child)")
+	expect(t, shared.Name, "app")
+
+	expect(t, New().Value(Type[*transformConfig]()).IsValid(), false)
+	plain := New()
+	plain.Map(shared)
+	expect(t, plain.Value(Type[*transformConfig]()).Interface(), shared)
+}
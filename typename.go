@@ -0,0 +1,79 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// typeNames maps the qualified names of the types registered with
+// RegisterTypeName to the types.
+var typeNames = struct {
+	sync.RWMutex
+	m map[string]reflect.Type
+}{m: make(map[string]reflect.Type)}
+
+// RegisterTypeName makes t known by its qualified name to ValueByName of every
+// injector, e.g. for an interface that is only bound through an implementation.
+// Bound types are found by name without registering them. It panics if a
+// different type with the same name has been registered.
+func RegisterTypeName(t reflect.Type) {
+	if t == nil {
+		panic("inject: RegisterTypeName of nil type")
+	}
+	name := qualifiedName(t)
+	typeNames.Lock()
+	defer typeNames.Unlock()
+	if prev, ok := typeNames.m[name]; ok && prev != t {
+		panic(fmt.Sprintf("inject: type name %q registered twice", name))
+	}
+	typeNames.m[name] = t
+}
+
+// registeredType returns the registered type named name, or nil.
+func registeredType(name string) reflect.Type {
+	typeNames.RLock()
+	defer typeNames.RUnlock()
+	return typeNames.m[name]
+}
+
+// canonicalName returns name with the stars of a pointer type moved in front of
+// the import path, so that "github.com/acme/db.*Client" is read as
+// "*github.com/acme/db.Client", also for generic types such as
+// "github.com/acme/db.*Repo[github.com/acme/model.User]".
+func canonicalName(name string) string {
+	if strings.HasPrefix(name, "*") {
+		return name
+	}
+	// Type arguments of generic types contain import paths as well.
+	head := name
+	if i := strings.IndexByte(name, '['); i >= 0 {
+		head = name[:i]
+	}
+	slash := strings.LastIndex(head, "/")
+	dot := strings.Index(head[slash+1:], ".*")
+	if dot < 0 {
+		return name
+	}
+	dot += slash + 1
+	base := strings.TrimLeft(name[dot+1:], "*")
+	stars := len(name) - dot - 1 - len(base)
+	return strings.Repeat("*", stars) + name[:dot+1] + base
+}
+
+func (inj *injector) ValueByName(name string) (reflect.Value, error) {
+	name = canonicalName(name)
+	t := registeredType(name)
+	if t == nil {
+		t = inj.typeNamed(name)
+	}
+	if t == nil {
+		return reflect.Value{}, fmt.Errorf("%w: no type named %q", ErrValueNotFound, name)
+	}
+	v, err := inj.resolveFor(t, "ValueByName")
+	if err == nil && !v.IsValid() {
+		err = inj.missing(t, "ValueByName")
+	}
+	return v, err
+}
@@ -0,0 +1,34 @@
+//go:build !inject_light
+
+package inject
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestValueByName(t *testing.T) {
+	inj := New()
+	inj.Map(&greeter{"Jeremy"})
+	child := inj.Child()
+
+	v, err := child.ValueByName("*github.com/juanjiTech/inject/v2.greeter")
+	expect(t, err, nil)
+	expect(t, v.Interface().(*greeter).Name, "Jeremy")
+
+	v, err = child.ValueByName("github.com/juanjiTech/inject/v2.*greeter")
+	expect(t, err, nil)
+	expect(t, v.Interface().(*greeter).Name, "Jeremy")
+
+	_, err = child.ValueByName("fmt.Stringer")
+	expect(t, errors.Is(err, ErrValueNotFound), true)
+
+	RegisterTypeName(Type[fmt.Stringer]())
+	v, err = child.ValueByName("fmt.Stringer")
+	expect(t, err, nil)
+	expect(t, v.Interface().(fmt.Stringer).String(), "Hello, My name isJeremy")
+
+	_, err = New().ValueByName("fmt.Stringer")
+	expect(t, errors.Is(err, ErrValueNotFound), true)
+}
@@ -0,0 +1,28 @@
+package inject
+
+import (
+	"testing"
+)
+
+func TestRegisterTypeName(t *testing.T) {
+	RegisterTypeName(Type[*testRepo]())
+	RegisterTypeName(Type[*testRepo]())
+	expect(t, registeredType("*github.com/juanjiTech/inject/v2.testRepo"), Type[*testRepo]())
+
+	defer func() {
+		expect(t, recover() != nil, true)
+	}()
+	RegisterTypeName(nil)
+}
+
+func TestCanonicalName(t *testing.T) {
+	for name, want := range map[string]string{
+		"github.com/acme/app/db.*Client":  "*github.com/acme/app/db.Client",
+		"github.com/acme/app/db.**Client": "**github.com/acme/app/db.Client",
+		"*github.com/acme/app/db.Client":  "*github.com/acme/app/db.Client",
+		"github.com/acme/app/db.Client":   "github.com/acme/app/db.Client",
+		"string":                          "string",
+	} {
+		expect(t, canonicalName(name), want)
+	}
+}
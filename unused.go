@@ -0,0 +1,55 @@
+package inject
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+)
+
+func (inj *injector) UnusedBindings() []reflect.Type {
+	inj.mu.RLock()
+	var unused []reflect.Type
+	for t, b := range inj.values {
+		if b.state != nil && !b.resolved() {
+			unused = append(unused, t)
+		}
+	}
+	inj.mu.RUnlock()
+	sort.Slice(unused, func(i, j int) bool { return unused[i].String() < unused[j].String() })
+	return unused
+}
+
+// ReportUnused returns a StopFunc writing a line for every unused binding of
+// inj to w, to be registered with Shutdown so that dead wiring is found in
+// the logs of a process.
+func ReportUnused(inj Injector, w io.Writer) StopFunc {
+	return NotifyUnused(inj, func(ctx context.Context, unused []reflect.Type) error {
+		for _, t := range unused {
+			if _, err := fmt.Fprintf(w, "inject: unused binding %v\n", t); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// NotifyUnused returns a StopFunc calling hook with the unused bindings of inj,
+// which is empty if all of them have been resolved. It is ReportUnused for
+// structured loggers and metrics, e.g.
+//
+//	s.Register("unused", inject.NotifyUnused(inj, func(ctx context.Context, unused []reflect.Type) error {
+//		unusedGauge.Set(float64(len(unused)))
+//		for _, t := range unused {
+//			slog.InfoContext(ctx, "unused binding", "type", t.String())
+//		}
+//		return nil
+//	}), inject.DependsOn("http"))
+//
+// Bindings are only tracked by injectors created with WithUsageTracking.
+func NotifyUnused(inj Injector, hook func(ctx context.Context, unused []reflect.Type) error) StopFunc {
+	return func(ctx context.Context) error {
+		return hook(ctx, inj.UnusedBindings())
+	}
+}
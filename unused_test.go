@@ -0,0 +1,45 @@
+package inject
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestInjector_UnusedBindings(t *testing.T) {
+	inj := New(WithUsageTracking())
+	inj.Map("a dep", 1, &greeter{"Jeremy"})
+	expect(t, inj.Provide(func() *testRepo { return &testRepo{} }), nil)
+	expect(t, len(inj.UnusedBindings()), 4)
+
+	_, err := inj.Child().Invoke(func(s string, r *testRepo) {})
+	expect(t, err, nil)
+
+	unused := inj.UnusedBindings()
+	expect(t, len(unused), 2)
+	expect(t, unused[0], Type[*greeter]())
+	expect(t, unused[1], Type[int]())
+
+	var buf bytes.Buffer
+	s := NewShutdown(0)
+	expect(t, s.Register("unused", ReportUnused(inj, &buf)), nil)
+	expect(t, s.Stop(context.Background()), nil)
+	expect(t, buf.String(), "inject: unused binding *inject.greeter\ninject: unused binding int\n")
+
+	var notified []reflect.Type
+	s = NewShutdown(0)
+	expect(t, s.Register("unused", NotifyUnused(inj, func(ctx context.Context, unused []reflect.Type) error {
+		notified = unused
+		return errors.New("metrics unavailable")
+	})), nil)
+	err = s.Stop(context.Background())
+	expect(t, err.Error(), "shutdown: unused: metrics unavailable")
+	expect(t, len(notified), 2)
+	expect(t, notified[1], Type[int]())
+
+	untracked := New()
+	untracked.Map("a dep")
+	expect(t, len(untracked.UnusedBindings()), 0)
+}
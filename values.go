@@ -0,0 +1,46 @@
+package inject
+
+import "reflect"
+
+func (inj *injector) Values(ts ...reflect.Type) ([]reflect.Value, error) {
+	out := make([]reflect.Value, len(ts))
+	if inj.plainHits() {
+		if !inj.frozen {
+			inj.mu.RLock()
+		}
+		for i, t := range ts {
+			if b := inj.values[t]; b.value.IsValid() && b.state == nil {
+				out[i] = b.value
+			}
+		}
+		if !inj.frozen {
+			inj.mu.RUnlock()
+		}
+	}
+	for i, t := range ts {
+		if out[i].IsValid() {
+			inj.counters.add(countLookups)
+			inj.counters.add(countHits)
+			continue
+		}
+		v, err := inj.resolveFor(t, "Values")
+		if err != nil {
+			return nil, err
+		}
+		if !v.IsValid() {
+			return nil, inj.missing(t, "Values")
+		}
+		out[i] = v
+	}
+	return out, nil
+}
+
+// plainHits reports whether resolving a value mapped into inj itself returns
+// it as is, without an option of inj observing or changing the resolution,
+// so that Values can read such values under a single lock and handlers of
+// Handle1 can reuse their arguments.
+func (inj *injector) plainHits() bool {
+	o := inj.opts
+	return o.trace == nil && o.transforms == nil && o.recorder == nil && o.resolveHook == nil &&
+		o.denied == nil && o.deprecated == nil && o.authorize == nil
+}
@@ -0,0 +1,33 @@
+//go:build !inject_light
+
+package inject
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestInjector_Values(t *testing.T) {
+	parent := New()
+	parent.Map(&greeter{"Jeremy"})
+	expect(t, parent.Provide(func() float64 { return 1.5 }), nil)
+	inj := parent.Child()
+	inj.Map("local", 1)
+
+	vs, err := inj.Values(Type[string](), Type[fmt.Stringer](), Type[int](), Type[float64]())
+	expect(t, err, nil)
+	expect(t, len(vs), 4)
+	expect(t, vs[0].String(), "local")
+	expect(t, vs[1].Interface().(fmt.Stringer).String(), "Hello, My name isJeremy")
+	expect(t, vs[2].Int(), int64(1))
+	expect(t, vs[3].Float(), 1.5)
+
+	_, err = inj.Values(Type[string](), Type[uint]())
+	expect(t, errors.Is(err, ErrValueNotFound), true)
+	expect(t, err.(*MissingDependencyError).Consumer, "Values")
+
+	vs, err = inj.Values()
+	expect(t, err, nil)
+	expect(t, len(vs), 0)
+}
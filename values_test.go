@@ -0,0 +1,35 @@
+package inject
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestInjector_Values_Options(t *testing.T) {
+	inj := New(WithStats(), Deny(Type[int]()), Transform(Type[string](), func(v reflect.Value) reflect.Value {
+		return reflect.ValueOf(v.String() + "!")
+	}))
+	inj.Map("hello", 1)
+	vs, err := inj.Values(Type[string]())
+	expect(t, err, nil)
+	expect(t, vs[0].String(), "hello!")
+	_, err = inj.Values(Type[string](), Type[int]())
+	expect(t, errors.Is(err, ErrDenied), true)
+
+	stats := New(WithStats())
+	stats.Map("hello")
+	_, _ = stats.Values(Type[string](), Type[string]())
+	expect(t, stats.Stats().Lookups, uint64(2))
+	expect(t, stats.Stats().Hits, uint64(2))
+}
+
+func BenchmarkInjector_Values(b *testing.B) {
+	inj := New()
+	inj.Map("a", 1, 1.5, &greeter{})
+	ts := []reflect.Type{Type[string](), Type[int](), Type[float64](), Type[*greeter]()}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = inj.Values(ts...)
+	}
+}
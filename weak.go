@@ -0,0 +1,119 @@
+package inject
+
+import (
+	"reflect"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// weakProviders holds the Weak providers whose results are constructed. Every
+// garbage collection cycle drops the results of those not resolved since the
+// previous one.
+var weakProviders = struct {
+	sync.Mutex
+	providers map[*provider]bool
+	armed     bool
+}{providers: make(map[*provider]bool)}
+
+// getWeak is get for Weak providers, whose results are read under p.mu as they
+// can be dropped.
+func (p *provider) getWeak(t reflect.Type, i int, prog *progress, chain *building) (reflect.Value, error) {
+	if err := chain.cycle(p, t); err != nil {
+		return reflect.Value{}, err
+	}
+	if err := p.lock(t); err != nil {
+		return reflect.Value{}, err
+	}
+	defer p.mu.Unlock()
+	if p.done == 0 {
+		if err := p.construct(t, prog, chain); err != nil {
+			return reflect.Value{}, err
+		}
+		watchWeak(p)
+	}
+	p.used = true
+	return p.value(i), nil
+}
+
+// peekWeak is peek for Weak providers. It returns an invalid value while the
+// results are being constructed.
+func (p *provider) peekWeak(i int) reflect.Value {
+	if !p.mu.TryLock() {
+		return reflect.Value{}
+	}
+	defer p.mu.Unlock()
+	if p.done == 0 {
+		return reflect.Value{}
+	}
+	return p.value(i)
+}
+
+// watchWeak registers p to have its results dropped, and makes sure that
+// garbage collection cycles are watched.
+func watchWeak(p *provider) {
+	weakProviders.Lock()
+	weakProviders.providers[p] = true
+	arm := !weakProviders.armed
+	weakProviders.armed = true
+	weakProviders.Unlock()
+	if arm {
+		armGCWatch()
+	}
+}
+
+// gcSentinel is an object whose finalizer runs after every garbage collection
+// cycle. It holds a pointer, so that it is not batched by the tiny allocator.
+type gcSentinel struct {
+	_ *int
+}
+
+func armGCWatch() {
+	runtime.SetFinalizer(&gcSentinel{}, func(*gcSentinel) {
+		if dropWeak() {
+			armGCWatch()
+		}
+	})
+}
+
+// dropWeak drops the results of the Weak providers not resolved since the
+// previous call, passing them to the OnExpire callbacks of their injectors,
+// and reports whether any providers are left to watch.
+func dropWeak() bool {
+	type dropped struct {
+		p      *provider
+		values []reflect.Value
+	}
+	var drops []dropped
+	weakProviders.Lock()
+	for p := range weakProviders.providers {
+		// A provider busy constructing is considered used.
+		if !p.mu.TryLock() {
+			continue
+		}
+		if p.used {
+			p.used = false
+		} else {
+			if atomic.LoadUint32(&p.inj.hasOnExpire) != 0 {
+				d := dropped{p: p, values: make([]reflect.Value, len(p.outs))}
+				for i := range p.outs {
+					d.values[i] = p.value(i)
+				}
+				drops = append(drops, d)
+			}
+			p.results = nil
+			atomic.StoreUint32(&p.done, 0)
+			delete(weakProviders.providers, p)
+		}
+		p.mu.Unlock()
+	}
+	armed := len(weakProviders.providers) > 0
+	weakProviders.armed = armed
+	weakProviders.Unlock()
+	for _, d := range drops {
+		for i, v := range d.values {
+			d.p.inj.expireDropped(d.p, i, v)
+		}
+	}
+	return armed
+}
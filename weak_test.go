@@ -0,0 +1,53 @@
+package inject
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type weakArtifact struct {
+	data []byte
+}
+
+// collectUntil runs garbage collection cycles until cond holds or a second
+// has passed, as finalizers run asynchronously.
+func collectUntil(cond func() bool) bool {
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		runtime.GC()
+		time.Sleep(time.Millisecond)
+		if cond() {
+			return true
+		}
+	}
+	return false
+}
+
+func TestWeakLifetime(t *testing.T) {
+	var built int32
+	inj := New()
+	expect(t, inj.Provide(func() *weakArtifact {
+		atomic.AddInt32(&built, 1)
+		return &weakArtifact{data: make([]byte, 1<<10)}
+	}, WithLifetime(Weak)), nil)
+	expect(t, Weak.String(), "weak")
+
+	a, err := LoadT[*weakArtifact](inj)
+	expect(t, err, nil)
+	b, err := LoadT[*weakArtifact](inj)
+	expect(t, err, nil)
+	expect(t, a, b)
+	expect(t, atomic.LoadInt32(&built), int32(1))
+
+	dropped := collectUntil(func() bool {
+		return !inj.Export()[Type[*weakArtifact]()].IsValid()
+	})
+	expect(t, dropped, true)
+
+	c, err := LoadT[*weakArtifact](inj)
+	expect(t, err, nil)
+	expect(t, c != a, true)
+	expect(t, atomic.LoadInt32(&built), int32(2))
+}